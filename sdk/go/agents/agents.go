@@ -0,0 +1,67 @@
+// Package agents provides the MCP client's agent resource operations:
+// querying and registering agents. Construct a Service with New, sharing
+// the transport.Client backing *mcp.Client; mcp.Client.Agents embeds one
+// for convenient access as c.Agents.Get(ctx, name).
+package agents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// Status represents agent status information.
+type Status struct {
+	Name         string   `json:"name"`
+	Status       string   `json:"status"`
+	LastSeen     string   `json:"lastSeen"`
+	HealthScore  float64  `json:"healthScore"`
+	Capabilities []string `json:"capabilities"`
+	ActiveTasks  int      `json:"activeTasks,omitempty"`
+	TotalTasks   int      `json:"totalTasks,omitempty"`
+}
+
+// Service provides agent resource operations against a shared
+// transport.Client.
+type Service struct {
+	client transport.Client
+}
+
+// New creates a Service backed by client.
+func New(client transport.Client) *Service {
+	return &Service{client: client}
+}
+
+// Get retrieves the status of a specific agent.
+func (s *Service) Get(ctx context.Context, name string, opts ...transport.CallOption) (*Status, error) {
+	var result Status
+	path := fmt.Sprintf("/agents/%s", name)
+	if err := s.client.Do(ctx, http.MethodGet, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// List lists all available agents.
+func (s *Service) List(ctx context.Context, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodGet, "/api/agents/status", nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Register registers a new agent with the given capabilities.
+func (s *Service) Register(ctx context.Context, name string, capabilities []string, opts ...transport.CallOption) (*Status, error) {
+	body := map[string]interface{}{
+		"name":         name,
+		"capabilities": capabilities,
+	}
+	var result Status
+	if err := s.client.Do(ctx, http.MethodPost, "/agents", body, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,87 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+type fakeClient struct {
+	method, path string
+	body         interface{}
+	resp         interface{}
+	err          error
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, path string, body, result interface{}, opts ...transport.CallOption) error {
+	f.method, f.path, f.body = method, path, body
+	if f.err != nil {
+		return f.err
+	}
+	if f.resp != nil && result != nil {
+		b, _ := json.Marshal(f.resp)
+		return json.Unmarshal(b, result)
+	}
+	return nil
+}
+
+func TestServiceMethodsIssueExpectedRequests(t *testing.T) {
+	cases := []struct {
+		name       string
+		call       func(s *Service, c *fakeClient) (interface{}, error)
+		wantMethod string
+		wantPath   string
+	}{
+		{
+			name: "Get",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				c.resp = Status{Name: "agent-1", Status: "online"}
+				return s.Get(context.Background(), "agent-1")
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/agents/agent-1",
+		},
+		{
+			name: "List",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				return s.List(context.Background())
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/api/agents/status",
+		},
+		{
+			name: "Register",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				c.resp = Status{Name: "agent-2"}
+				return s.Register(context.Background(), "agent-2", []string{"lint"})
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/agents",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeClient{}
+			s := New(c)
+			_, err := tc.call(s, c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMethod, c.method)
+			assert.Equal(t, tc.wantPath, c.path)
+		})
+	}
+}
+
+func TestGetReturnsServiceError(t *testing.T) {
+	c := &fakeClient{err: assert.AnError}
+	s := New(c)
+
+	_, err := s.Get(context.Background(), "agent-1")
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,73 @@
+// Package ai provides the MCP client's AI-powered operations: code
+// analysis, performance prediction, and code generation. Construct a
+// Service with New, sharing the transport.Client backing *mcp.Client;
+// mcp.Client.AI embeds one for convenient access as
+// c.AI.AnalyzeCode(ctx, req).
+package ai
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// CodeAnalysisRequest represents a code analysis request.
+type CodeAnalysisRequest struct {
+	Code     string            `json:"code"`
+	Language string            `json:"language,omitempty"`
+	Options  map[string]bool   `json:"options,omitempty"`
+	Context  map[string]string `json:"context,omitempty"`
+}
+
+// LogString returns a safe-to-log representation of r.
+func (r CodeAnalysisRequest) LogString() string { return transport.LogString(r) }
+
+// CodeGenerationRequest represents a code generation request.
+type CodeGenerationRequest struct {
+	Description string   `json:"description"`
+	Language    string   `json:"language,omitempty"`
+	Context     string   `json:"context,omitempty"`
+	Constraints []string `json:"constraints,omitempty"`
+}
+
+// LogString returns a safe-to-log representation of r.
+func (r CodeGenerationRequest) LogString() string { return transport.LogString(r) }
+
+// Service provides AI resource operations against a shared
+// transport.Client.
+type Service struct {
+	client transport.Client
+}
+
+// New creates a Service backed by client.
+func New(client transport.Client) *Service {
+	return &Service{client: client}
+}
+
+// AnalyzeCode performs AI-powered code analysis.
+func (s *Service) AnalyzeCode(ctx context.Context, req CodeAnalysisRequest, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodPost, "/ai/analyze", req, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PredictPerformance predicts performance metrics.
+func (s *Service) PredictPerformance(ctx context.Context, metrics map[string]interface{}, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodPost, "/ai/predict", metrics, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GenerateCode generates code from a description.
+func (s *Service) GenerateCode(ctx context.Context, req CodeGenerationRequest, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodPost, "/ai/generate", req, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
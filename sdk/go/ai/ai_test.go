@@ -0,0 +1,75 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+type fakeClient struct {
+	method, path string
+	body         interface{}
+	err          error
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, path string, body, result interface{}, opts ...transport.CallOption) error {
+	f.method, f.path, f.body = method, path, body
+	return f.err
+}
+
+func TestServiceMethodsIssueExpectedRequests(t *testing.T) {
+	cases := []struct {
+		name       string
+		call       func(s *Service) (interface{}, error)
+		wantMethod string
+		wantPath   string
+	}{
+		{
+			name: "AnalyzeCode",
+			call: func(s *Service) (interface{}, error) {
+				return s.AnalyzeCode(context.Background(), CodeAnalysisRequest{Code: "package main"})
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/ai/analyze",
+		},
+		{
+			name: "PredictPerformance",
+			call: func(s *Service) (interface{}, error) {
+				return s.PredictPerformance(context.Background(), map[string]interface{}{"cpu": 0.5})
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/ai/predict",
+		},
+		{
+			name: "GenerateCode",
+			call: func(s *Service) (interface{}, error) {
+				return s.GenerateCode(context.Background(), CodeGenerationRequest{Description: "a CLI flag parser"})
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/ai/generate",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeClient{}
+			s := New(c)
+			_, err := tc.call(s)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMethod, c.method)
+			assert.Equal(t, tc.wantPath, c.path)
+		})
+	}
+}
+
+func TestCodeAnalysisRequestLogString(t *testing.T) {
+	req := CodeAnalysisRequest{Code: "package main", Language: "go"}
+	out := req.LogString()
+	assert.Contains(t, out, "package main")
+	assert.Contains(t, out, "go")
+}
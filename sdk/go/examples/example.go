@@ -9,6 +9,9 @@ import (
 	"time"
 
 	mcp "github.com/dboone323/tools-automation/sdk/go"
+	"github.com/dboone323/tools-automation/sdk/go/ai"
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+	"github.com/dboone323/tools-automation/sdk/go/webhooks"
 )
 
 func main() {
@@ -72,7 +75,7 @@ func basicServerExample(ctx context.Context, client *mcp.Client) {
 
 	// List controllers/agents
 	fmt.Println("🤖 Listing available agents...")
-	agents, err := client.ListControllers(ctx)
+	agents, err := client.Agents.List(ctx)
 	if err != nil {
 		log.Printf("❌ Error listing agents: %v", err)
 		return
@@ -86,7 +89,7 @@ func taskManagementExample(ctx context.Context, client *mcp.Client) {
 
 	// Submit a code analysis task
 	fmt.Println("🔍 Submitting code analysis task...")
-	task := mcp.TaskSubmission{
+	task := tasks.Submission{
 		Type:     "code_analysis",
 		Target:   "example.go",
 		Priority: "normal",
@@ -97,7 +100,7 @@ func taskManagementExample(ctx context.Context, client *mcp.Client) {
 		},
 	}
 
-	taskResult, err := client.SubmitTask(ctx, task)
+	taskResult, err := client.Tasks.Submit(ctx, task)
 	if err != nil {
 		log.Printf("❌ Error submitting task: %v", err)
 		return
@@ -110,7 +113,7 @@ func taskManagementExample(ctx context.Context, client *mcp.Client) {
 		if taskData, ok := (*taskResult)["data"].(map[string]interface{}); ok {
 			if taskID, ok := taskData["id"].(string); ok {
 				fmt.Printf("📊 Checking status of task %s...\n", taskID)
-				status, err := client.GetTaskStatus(ctx, taskID)
+				status, err := client.Tasks.Get(ctx, taskID)
 				if err != nil {
 					log.Printf("❌ Error getting task status: %v", err)
 				} else {
@@ -122,12 +125,12 @@ func taskManagementExample(ctx context.Context, client *mcp.Client) {
 
 	// List recent tasks
 	fmt.Println("📝 Listing recent tasks...")
-	tasks, err := client.ListTasks(ctx, "", "")
+	recentTasks, err := client.Tasks.List(ctx, "", "")
 	if err != nil {
 		log.Printf("❌ Error listing tasks: %v", err)
 		return
 	}
-	printJSON("Recent tasks", tasks)
+	printJSON("Recent tasks", recentTasks)
 }
 
 func aiFeaturesExample(ctx context.Context, client *mcp.Client) {
@@ -144,7 +147,7 @@ func main() {
 
 	// Analyze code
 	fmt.Println("🔬 Analyzing code...")
-	analysis := mcp.CodeAnalysisRequest{
+	analysis := ai.CodeAnalysisRequest{
 		Code:     sampleCode,
 		Language: "go",
 		Options: map[string]bool{
@@ -157,7 +160,7 @@ func main() {
 		},
 	}
 
-	analysisResult, err := client.AnalyzeCode(ctx, analysis)
+	analysisResult, err := client.AI.AnalyzeCode(ctx, analysis)
 	if err != nil {
 		log.Printf("❌ Error analyzing code: %v", err)
 	} else {
@@ -166,7 +169,7 @@ func main() {
 
 	// Generate code
 	fmt.Println("💡 Generating code...")
-	generation := mcp.CodeGenerationRequest{
+	generation := ai.CodeGenerationRequest{
 		Description: "Create a function to calculate factorial in Go",
 		Language:    "go",
 		Context:     "mathematical utilities",
@@ -177,7 +180,7 @@ func main() {
 		},
 	}
 
-	genResult, err := client.GenerateCode(ctx, generation)
+	genResult, err := client.AI.GenerateCode(ctx, generation)
 	if err != nil {
 		log.Printf("❌ Error generating code: %v", err)
 	} else {
@@ -193,7 +196,7 @@ func main() {
 		"error_rate":   0.01,
 	}
 
-	prediction, err := client.PredictPerformance(ctx, metrics)
+	prediction, err := client.AI.PredictPerformance(ctx, metrics)
 	if err != nil {
 		log.Printf("❌ Error predicting performance: %v", err)
 	} else {
@@ -207,7 +210,7 @@ func pluginManagementExample(ctx context.Context, client *mcp.Client) {
 
 	// List available plugins
 	fmt.Println("📦 Listing available plugins...")
-	plugins, err := client.ListPlugins(ctx)
+	plugins, err := client.Plugins.List(ctx)
 	if err != nil {
 		log.Printf("❌ Error listing plugins: %v", err)
 		return
@@ -215,11 +218,11 @@ func pluginManagementExample(ctx context.Context, client *mcp.Client) {
 	printJSON("Available plugins", plugins)
 
 	// If plugins exist, get info about the first one
-	if plugins != nil && plugins.Data != nil && len(plugins.Data) > 0 {
-		pluginName := plugins.Data[0].Name
+	if len(plugins) > 0 {
+		pluginName := plugins[0].Name
 		fmt.Printf("ℹ️  Getting info for plugin: %s\n", pluginName)
 
-		info, err := client.GetPluginInfo(ctx, pluginName)
+		info, err := client.Plugins.Get(ctx, pluginName)
 		if err != nil {
 			log.Printf("❌ Error getting plugin info: %v", err)
 		} else {
@@ -234,7 +237,7 @@ func pluginManagementExample(ctx context.Context, client *mcp.Client) {
 		//         "log_level": "info",
 		//     },
 		// }
-		// installResult, err := client.InstallPlugin(ctx, pluginName, config)
+		// installResult, err := client.Plugins.Install(ctx, pluginName, config)
 		// if err != nil {
 		//     log.Printf("❌ Error installing plugin: %v", err)
 		// } else {
@@ -249,13 +252,13 @@ func webhookExample(ctx context.Context, client *mcp.Client) {
 
 	// Register a webhook
 	fmt.Println("📡 Registering webhook...")
-	webhook := mcp.WebhookRegistration{
+	webhook := webhooks.Registration{
 		URL:    "https://example.com/webhook/mcp-events",
 		Events: []string{"task.completed", "agent.status_changed", "system.alert"},
 		Secret: "webhook-secret-key-12345",
 	}
 
-	regResult, err := client.RegisterWebhook(ctx, webhook)
+	regResult, err := client.Webhooks.Register(ctx, webhook)
 	if err != nil {
 		log.Printf("❌ Error registering webhook: %v", err)
 	} else {
@@ -264,11 +267,11 @@ func webhookExample(ctx context.Context, client *mcp.Client) {
 
 	// List webhooks
 	fmt.Println("📋 Listing webhooks...")
-	webhooks, err := client.ListWebhooks(ctx)
+	registeredWebhooks, err := client.Webhooks.List(ctx)
 	if err != nil {
 		log.Printf("❌ Error listing webhooks: %v", err)
 	} else {
-		printJSON("Registered webhooks", webhooks)
+		printJSON("Registered webhooks", registeredWebhooks)
 	}
 }
 
@@ -293,13 +296,13 @@ func errorHandlingExample(ctx context.Context) {
 	validClient := mcp.NewClient("http://localhost:5005", nil)
 
 	// Try to get status of non-existent agent
-	_, err = validClient.GetAgentStatus(ctx, "non-existent-agent-12345")
+	_, err = validClient.Agents.Get(ctx, "non-existent-agent-12345")
 	if err != nil {
 		fmt.Printf("✅ Expected API error: %T - %v\n", err, err)
 	}
 
 	// Try to cancel non-existent task
-	_, err = validClient.CancelTask(ctx, "invalid-task-id-12345")
+	_, err = validClient.Tasks.Cancel(ctx, "invalid-task-id-12345")
 	if err != nil {
 		fmt.Printf("✅ Expected cancellation error: %T - %v\n", err, err)
 	}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// Logger is the structured logging interface the SDK emits request/response
+// events through. Implementations receive a message and an even-length list
+// of alternating keys and values, mirroring log/slog's convention so that
+// adapting an existing slog.Logger is a one-line wrapper (see NewSlogLogger).
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// noopLogger discards everything. It is the default so users who don't
+// configure ClientOptions.Logger pay no logging overhead.
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})  {}
+func (noopLogger) Warn(string, ...interface{})  {}
+func (noopLogger) Error(string, ...interface{}) {}
+
+// slogLogger adapts a *slog.Logger to the Logger interface.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger wraps l so it can be used as ClientOptions.Logger.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{l: l}
+}
+
+func (s *slogLogger) Debug(msg string, kv ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelDebug, msg, kv...)
+}
+
+func (s *slogLogger) Info(msg string, kv ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelInfo, msg, kv...)
+}
+
+func (s *slogLogger) Warn(msg string, kv ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelWarn, msg, kv...)
+}
+
+func (s *slogLogger) Error(msg string, kv ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelError, msg, kv...)
+}
+
+// newRequestID returns a short hex identifier used to correlate the log
+// lines emitted for a single outbound call.
+func newRequestID() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b[:])
+}
+
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger captures every log line so tests can assert on it.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (r *recordingLogger) record(msg string, kv ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, v := range kv {
+		b.WriteString(" ")
+		b.WriteString(toString(v))
+	}
+	r.lines = append(r.lines, b.String())
+}
+
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return "x"
+}
+
+func (r *recordingLogger) Debug(msg string, kv ...interface{}) { r.record(msg, kv...) }
+func (r *recordingLogger) Info(msg string, kv ...interface{})  { r.record(msg, kv...) }
+func (r *recordingLogger) Warn(msg string, kv ...interface{})  { r.record(msg, kv...) }
+func (r *recordingLogger) Error(msg string, kv ...interface{}) { r.record(msg, kv...) }
+
+func (r *recordingLogger) all() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.lines, "\n")
+}
+
+func TestMakeRequestLogsSuccess(t *testing.T) {
+	server := setupTestServer()
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient(server.URL, &ClientOptions{Logger: logger})
+
+	_, err := client.GetStatus(context.Background())
+	require.NoError(t, err)
+
+	assert.Contains(t, logger.all(), "mcp request succeeded")
+	assert.Contains(t, logger.all(), "/status")
+}
+
+func TestMakeRequestLogsMCPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"ok": false, "error": "not found"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient(server.URL, &ClientOptions{Logger: logger})
+
+	_, err := client.GetStatus(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, logger.all(), "mcp request returned an error")
+}
@@ -6,15 +6,37 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/dboone323/tools-automation/sdk/go/agents"
+	"github.com/dboone323/tools-automation/sdk/go/ai"
+	"github.com/dboone323/tools-automation/sdk/go/metrics"
+	"github.com/dboone323/tools-automation/sdk/go/plugins"
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+	"github.com/dboone323/tools-automation/sdk/go/webhooks"
 )
 
-// Client represents an MCP server client
+// Client represents an MCP server client. Resource-specific operations
+// live on its Agents, Tasks, AI, Webhooks, and Plugins services (e.g.
+// c.Agents.Get(ctx, name), c.Tasks.Submit(ctx, submission)); Client itself
+// keeps only the server-level operations (GetStatus, GetHealth, Register)
+// and the request plumbing those services are built on.
 type Client struct {
 	baseURL    string
 	httpClient *resty.Client
+	opts       *ClientOptions
+	auth       *authState
+	transport  Transport
+
+	Agents   *agents.Service
+	Tasks    *tasks.Service
+	AI       *ai.Service
+	Webhooks *webhooks.Service
+	Plugins  *plugins.Service
 }
 
 // ClientOptions contains configuration options for the MCP client
@@ -23,47 +45,112 @@ type ClientOptions struct {
 	MaxRetries int
 	RetryDelay time.Duration
 	Headers    map[string]string
+
+	// MachineID and Password enable machine-credential authentication. When
+	// MachineID is non-empty, the client logs in against LoginPath to obtain
+	// a bearer token and transparently refreshes it as it nears expiry or
+	// when the server challenges a request with 401.
+	MachineID        string
+	Password         string
+	Scenarios        []string
+	LoginPath        string
+	RegisterPath     string
+	TokenRefreshSkew time.Duration
+
+	// Logger receives a structured event for every outbound call. It
+	// defaults to a no-op logger; inject NewSlogLogger(slog.Default()) (or
+	// any Logger implementation) to observe SDK traffic.
+	Logger Logger
+
+	// PreferWebSocket makes SubscribeTasks and SubscribeAgentStatus stream
+	// over a WebSocket connection instead of the default SSE transport.
+	PreferWebSocket bool
+	// EventChannelCapacity sets the buffer size of a subscription's event
+	// channel. Once full, new events are dropped and counted in
+	// SubscriptionStats.DroppedEvents rather than blocking the transport
+	// loop. Defaults to 64.
+	EventChannelCapacity int
+
+	// Transport, when set, makes the Client dispatch every typed method
+	// (GetStatus, c.Tasks.Submit, ...) as a JSON-RPC 2.0 call through it
+	// instead of the default bespoke REST surface. See StdioTransport,
+	// HTTPTransport, and SSETransport.
+	Transport Transport
+
+	// Metrics, when set, records Prometheus counters and histograms for
+	// every outbound call. See package metrics for the collected series
+	// and NewMetricsCollector/DefaultCollector for constructing one.
+	Metrics *metrics.MetricsCollector
 }
 
 // DefaultClientOptions returns default client options
 func DefaultClientOptions() *ClientOptions {
 	return &ClientOptions{
-		Timeout:    30 * time.Second,
-		MaxRetries: 3,
-		RetryDelay: 1 * time.Second,
-		Headers:    make(map[string]string),
+		Timeout:          30 * time.Second,
+		MaxRetries:       3,
+		RetryDelay:       1 * time.Second,
+		Headers:          make(map[string]string),
+		LoginPath:        "/v1/watchers/login",
+		RegisterPath:     "/v1/watchers",
+		TokenRefreshSkew: 30 * time.Second,
 	}
 }
 
+// authState holds the cached bearer token for machine-credential auth.
+// Refresh attempts are serialized under mu so a burst of concurrent requests
+// triggers at most one login.
+type authState struct {
+	mu     sync.Mutex
+	token  string
+	expire time.Time
+}
+
 // NewClient creates a new MCP client
 func NewClient(baseURL string, opts *ClientOptions) *Client {
 	if opts == nil {
 		opts = DefaultClientOptions()
 	}
+	if opts.LoginPath == "" {
+		opts.LoginPath = "/v1/watchers/login"
+	}
+	if opts.RegisterPath == "" {
+		opts.RegisterPath = "/v1/watchers"
+	}
+	if opts.TokenRefreshSkew == 0 {
+		opts.TokenRefreshSkew = 30 * time.Second
+	}
+	if opts.Logger == nil {
+		opts.Logger = noopLogger{}
+	}
 
+	// Retries are driven explicitly by Do's classified retry loop rather than
+	// resty's own SetRetryCount, which retries every non-2xx/connection
+	// failure indiscriminately - including POSTs like /run that aren't safe
+	// to resend blindly.
 	httpClient := resty.New().
 		SetBaseURL(baseURL).
 		SetTimeout(opts.Timeout).
-		SetRetryCount(opts.MaxRetries).
-		SetRetryWaitTime(opts.RetryDelay).
-		SetRetryMaxWaitTime(30 * time.Second).
 		SetHeaders(opts.Headers).
 		SetHeader("Content-Type", "application/json").
 		SetHeader("User-Agent", "mcp-go-sdk/1.0.0")
 
-	return &Client{
+	c := &Client{
 		baseURL:    baseURL,
 		httpClient: httpClient,
+		opts:       opts,
+		transport:  opts.Transport,
+	}
+	if opts.MachineID != "" {
+		c.auth = &authState{}
 	}
-}
 
-// Response represents a standard MCP API response
-type Response[T any] struct {
-	Success     bool   `json:"success"`
-	Data        T      `json:"data,omitempty"`
-	Error       string `json:"error,omitempty"`
-	StatusCode  int    `json:"statusCode"`
-	ResponseTime int64  `json:"responseTime"`
+	c.Agents = agents.New(c)
+	c.Tasks = tasks.New(c)
+	c.AI = ai.New(c)
+	c.Webhooks = webhooks.New(c)
+	c.Plugins = plugins.New(c)
+
+	return c
 }
 
 // Error types
@@ -97,92 +184,242 @@ type ServerStatus struct {
 	LastChecked time.Time `json:"lastChecked,omitempty"`
 }
 
-// AgentStatus represents agent status information
-type AgentStatus struct {
-	Name          string   `json:"name"`
-	Status        string   `json:"status"`
-	LastSeen      string   `json:"lastSeen"`
-	HealthScore   float64  `json:"healthScore"`
-	Capabilities  []string `json:"capabilities"`
-	ActiveTasks   int      `json:"activeTasks,omitempty"`
-	TotalTasks    int      `json:"totalTasks,omitempty"`
+// loginResponse is the payload returned by the machine-credential login
+// endpoint: a bearer token and its expiration timestamp.
+type loginResponse struct {
+	Token  string `json:"token"`
+	Expire string `json:"expire"`
 }
 
-// TaskInfo represents task information
-type TaskInfo struct {
-	ID          string                 `json:"id"`
-	Status      string                 `json:"status"`
-	Type        string                 `json:"type"`
-	Agent       string                 `json:"agent"`
-	CreatedAt   string                 `json:"createdAt"`
-	CompletedAt string                 `json:"completedAt,omitempty"`
-	Result      map[string]interface{} `json:"result,omitempty"`
-	Error       string                 `json:"error,omitempty"`
-	Priority    string                 `json:"priority,omitempty"`
-	Progress    float64                `json:"progress,omitempty"`
-}
+// Register registers a machine with the server (`POST <RegisterPath>`). A
+// 403 response is treated as success since it indicates the machine is
+// already registered, making Register safe to call idempotently on startup.
+func (c *Client) Register(ctx context.Context, machineID, password string) error {
+	body := map[string]interface{}{
+		"machine_id": machineID,
+		"password":   password,
+	}
+	if len(c.opts.Scenarios) > 0 {
+		body["scenarios"] = c.opts.Scenarios
+	}
 
-// TaskSubmission represents a task submission request
-type TaskSubmission struct {
-	Type       string                 `json:"type"`
-	Target     string                 `json:"target,omitempty"`
-	Parameters map[string]interface{} `json:"parameters,omitempty"`
-	Priority   string                 `json:"priority,omitempty"`
-	Agent      string                 `json:"agent,omitempty"`
-}
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBody(body).
+		Post(c.opts.RegisterPath)
+	if err != nil {
+		return ConnectionError{Err: err}
+	}
 
-// CodeAnalysisRequest represents a code analysis request
-type CodeAnalysisRequest struct {
-	Code     string            `json:"code"`
-	Language string            `json:"language,omitempty"`
-	Options  map[string]bool   `json:"options,omitempty"`
-	Context  map[string]string `json:"context,omitempty"`
+	if resp.StatusCode() == http.StatusForbidden {
+		return nil
+	}
+	if resp.StatusCode() >= 300 {
+		return MCPError{
+			StatusCode: resp.StatusCode(),
+			Message:    string(resp.Body()),
+		}
+	}
+	return nil
 }
 
-// CodeGenerationRequest represents a code generation request
-type CodeGenerationRequest struct {
-	Description string   `json:"description"`
-	Language    string   `json:"language,omitempty"`
-	Context     string   `json:"context,omitempty"`
-	Constraints []string `json:"constraints,omitempty"`
-}
+// login exchanges machine credentials for a bearer token and caches it.
+// Callers must hold c.auth.mu.
+func (c *Client) login(ctx context.Context) error {
+	body := map[string]interface{}{
+		"machine_id": c.opts.MachineID,
+		"password":   c.opts.Password,
+	}
+	if len(c.opts.Scenarios) > 0 {
+		body["scenarios"] = c.opts.Scenarios
+	}
+
+	var login loginResponse
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetResult(&login).
+		Post(c.opts.LoginPath)
+	if err != nil {
+		return ConnectionError{Err: err}
+	}
+	if resp.StatusCode() >= 300 {
+		return MCPError{
+			StatusCode: resp.StatusCode(),
+			Message:    fmt.Sprintf("login failed: %s", resp.Body()),
+		}
+	}
+
+	expire, err := time.Parse(time.RFC3339, login.Expire)
+	if err != nil {
+		return fmt.Errorf("failed to parse token expiry %q: %w", login.Expire, err)
+	}
 
-// WebhookRegistration represents a webhook registration
-type WebhookRegistration struct {
-	URL    string   `json:"url"`
-	Events []string `json:"events"`
-	Secret string   `json:"secret,omitempty"`
+	c.auth.token = login.Token
+	c.auth.expire = expire
+	return nil
 }
 
-// PluginInfo represents plugin information
-type PluginInfo struct {
-	Name         string   `json:"name"`
-	Version      string   `json:"version"`
-	Description  string   `json:"description,omitempty"`
-	Capabilities []string `json:"capabilities"`
-	Status       string   `json:"status"`
-	InstalledAt  string   `json:"installedAt,omitempty"`
+// ensureToken refreshes the cached bearer token if it is missing or within
+// TokenRefreshSkew of expiry. Refreshes are serialized by c.auth.mu so a
+// burst of concurrent requests triggers at most one login.
+func (c *Client) ensureToken(ctx context.Context) error {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	if c.auth.token != "" && time.Until(c.auth.expire) > c.opts.TokenRefreshSkew {
+		return nil
+	}
+	return c.login(ctx)
+}
+
+// forceRefreshToken discards the cached token and logs in again. Used when
+// the server challenges a request with 401 despite a locally "valid" token.
+func (c *Client) forceRefreshToken(ctx context.Context) error {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	c.auth.token = ""
+	return c.login(ctx)
+}
+
+// shouldRetry reports whether err is safe for Do's retry loop to retry
+// automatically: ConnectionError (the request may never have reached the
+// server) and a 5xx MCPError. A 4xx MCPError is never retried - the server
+// has already rejected the request, and blindly resending a non-idempotent
+// POST risks duplicating it (see transport.WithIdempotencyKey).
+func shouldRetry(err error) bool {
+	switch e := err.(type) {
+	case ConnectionError:
+		return true
+	case MCPError:
+		return e.StatusCode >= 500
+	default:
+		return false
+	}
+}
+
+// Do performs an HTTP request and handles the response, implementing the
+// transport.Client seam the agents/tasks/ai/webhooks/plugins services issue
+// their requests through. When ClientOptions.Transport is set, it instead
+// dispatches the equivalent JSON-RPC call through that transport,
+// translating the REST (method, path, body) triple those services were
+// written against into an RPC method name and params via
+// rpcMethodForPath/rpcParamsForPath.
+//
+// opts can bound the call with a deadline or timeout (transport.WithTimeout,
+// transport.WithDeadline), override the retry policy for this call alone
+// (transport.WithRetry), and attach an idempotency key or extra headers
+// (transport.WithIdempotencyKey, transport.WithHeader). The deadline/timeout
+// is applied to ctx before either dispatch path runs, so it bounds a
+// JSON-RPC call the same way it bounds a REST one; the retry policy,
+// idempotency key, and extra headers are forwarded to c.transport.Call as
+// well, though what a Transport implementation can do with them varies (see
+// Transport.Call). A retryable failure (see shouldRetry) is retried up to
+// the resolved policy's MaxRetries, waiting Backoff between attempts; a 401
+// triggers at most one re-login and retry independent of that policy. Retry
+// is only handled here for the REST dispatch path - a configured Transport
+// is responsible for its own retries, if any.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}, result interface{}, opts ...transport.CallOption) error {
+	cfg := transport.ApplyCallOptions(opts)
+	ctx, cancel := cfg.Context(ctx)
+	defer cancel()
+
+	if c.transport != nil {
+		return c.transport.Call(ctx, rpcMethodForPath(method, path), rpcParamsForPath(path, body), result, opts...)
+	}
+
+	if c.auth != nil {
+		if err := c.ensureToken(ctx); err != nil {
+			return err
+		}
+	}
+
+	policy := transport.RetryPolicy{MaxRetries: c.opts.MaxRetries, Backoff: c.opts.RetryDelay}
+	if cfg.Retry != nil {
+		policy = *cfg.Retry
+	}
+
+	reauthed := false
+	var err error
+	for attempt := 0; ; attempt++ {
+		_, err = c.doRequest(ctx, method, path, body, result, attempt, cfg)
+		if err == nil {
+			return nil
+		}
+
+		// A 401 may mean the cached token expired early or was revoked
+		// server-side; re-login once and retry before giving up, regardless
+		// of the retry policy above.
+		if mcpErr, ok := err.(MCPError); ok && mcpErr.StatusCode == http.StatusUnauthorized && c.auth != nil && !reauthed {
+			reauthed = true
+			if refreshErr := c.forceRefreshToken(ctx); refreshErr != nil {
+				return refreshErr
+			}
+			c.opts.Metrics.RecordRetry(path)
+			continue
+		}
+
+		if attempt >= policy.MaxRetries || !shouldRetry(err) {
+			return err
+		}
+		c.opts.Metrics.RecordRetry(path)
+		if policy.Backoff > 0 {
+			select {
+			case <-time.After(policy.Backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
 }
 
-// makeRequest performs an HTTP request and handles the response
-func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+// doRequest issues a single HTTP request and decodes the MCP response
+// envelope, without any retry logic of its own. retryCount is logged as-is;
+// it reflects Do's retry loop, not resty's transport behavior. cfg supplies
+// the idempotency key and extra headers for this call, if any.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}, retryCount int, cfg transport.CallConfig) (*resty.Response, error) {
+	start := time.Now()
+	reqID := newRequestID()
+	finish := c.opts.Metrics.ObserveStart(method, path)
+
 	req := c.httpClient.R().
 		SetContext(ctx).
 		SetResult(&result)
 
+	if c.auth != nil {
+		c.auth.mu.Lock()
+		token := c.auth.token
+		c.auth.mu.Unlock()
+		req.SetHeader("Authorization", "Bearer "+token)
+	}
+
+	if cfg.IdempotencyKey != "" {
+		req.SetHeader("Idempotency-Key", cfg.IdempotencyKey)
+	}
+	for k, v := range cfg.Headers {
+		req.SetHeader(k, v)
+	}
+
 	if body != nil {
 		req.SetBody(body)
 	}
 
 	resp, err := req.Execute(method, path)
 	if err != nil {
-		return ConnectionError{Err: err}
+		c.opts.Logger.Error("mcp request failed",
+			"request_id", reqID, "method", method, "path", path,
+			"latency", time.Since(start), "retry_count", retryCount,
+			"outcome", "connection_error", "error", err.Error())
+		finish("connection_error")
+		return nil, ConnectionError{Err: err}
 	}
 
 	// Parse MCP server response format: {"ok": true, "data": {...}} or {"ok": true, "status": {...}}
 	var mcpResp map[string]interface{}
 	if err := json.Unmarshal(resp.Body(), &mcpResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		finish("mcp_error")
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Check if request was successful
@@ -192,7 +429,12 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 		if errStr, exists := mcpResp["error"]; exists {
 			errorMsg = fmt.Sprintf("%v", errStr)
 		}
-		return MCPError{
+		c.opts.Logger.Warn("mcp request returned an error",
+			"request_id", reqID, "method", method, "path", path,
+			"status", statusCode, "latency", time.Since(start), "retry_count", retryCount,
+			"outcome", "mcp_error")
+		finish("mcp_error")
+		return resp, MCPError{
 			StatusCode: statusCode,
 			Message:    errorMsg,
 			Response:   mcpResp,
@@ -218,20 +460,27 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 	if data != nil && result != nil {
 		dataBytes, err := json.Marshal(data)
 		if err != nil {
-			return fmt.Errorf("failed to marshal response data: %w", err)
+			finish("mcp_error")
+			return resp, fmt.Errorf("failed to marshal response data: %w", err)
 		}
 		if err := json.Unmarshal(dataBytes, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response data: %w", err)
+			finish("mcp_error")
+			return resp, fmt.Errorf("failed to unmarshal response data: %w", err)
 		}
 	}
 
-	return nil
+	c.opts.Logger.Debug("mcp request succeeded",
+		"request_id", reqID, "method", method, "path", path,
+		"status", resp.StatusCode(), "latency", time.Since(start), "retry_count", retryCount,
+		"outcome", "ok")
+	finish("ok")
+	return resp, nil
 }
 
 // GetStatus retrieves server status
-func (c *Client) GetStatus(ctx context.Context) (*ServerStatus, error) {
+func (c *Client) GetStatus(ctx context.Context, opts ...transport.CallOption) (*ServerStatus, error) {
 	var result ServerStatus
-	err := c.makeRequest(ctx, http.MethodGet, "/status", nil, &result)
+	err := c.Do(ctx, http.MethodGet, "/status", nil, &result, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -239,265 +488,11 @@ func (c *Client) GetStatus(ctx context.Context) (*ServerStatus, error) {
 }
 
 // GetHealth performs a health check
-func (c *Client) GetHealth(ctx context.Context) (*map[string]interface{}, error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodGet, "/health", nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
-// ListControllers lists all available agents
-func (c *Client) ListControllers(ctx context.Context) (*map[string]interface{}, error) {
+func (c *Client) GetHealth(ctx context.Context, opts ...transport.CallOption) (*map[string]interface{}, error) {
 	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodGet, "/api/agents/status", nil, &result)
+	err := c.Do(ctx, http.MethodGet, "/health", nil, &result, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
-
-// GetAgentStatus gets status of a specific agent
-func (c *Client) GetAgentStatus(ctx context.Context, agentName string) (*Response[AgentStatus], error) {
-	var result AgentStatus
-	path := fmt.Sprintf("/agents/%s", agentName)
-	err := c.makeRequest(ctx, http.MethodGet, path, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[AgentStatus]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// RegisterAgent registers a new agent
-func (c *Client) RegisterAgent(ctx context.Context, name string, capabilities []string) (*Response[AgentStatus], error) {
-	body := map[string]interface{}{
-		"name":         name,
-		"capabilities": capabilities,
-	}
-	var result AgentStatus
-	err := c.makeRequest(ctx, http.MethodPost, "/agents", body, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[AgentStatus]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   201,
-		ResponseTime: 0,
-	}, nil
-}
-
-// SubmitTask submits a task for processing
-func (c *Client) SubmitTask(ctx context.Context, task TaskSubmission) (*map[string]interface{}, error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodPost, "/run", task, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
-// GetTaskStatus gets the status of a task
-func (c *Client) GetTaskStatus(ctx context.Context, taskID string) (*Response[TaskInfo], error) {
-	var result TaskInfo
-	path := fmt.Sprintf("/tasks/%s", taskID)
-	err := c.makeRequest(ctx, http.MethodGet, path, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[TaskInfo]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// ListTasks lists tasks with optional filtering
-func (c *Client) ListTasks(ctx context.Context, status, agent string) (*map[string]interface{}, error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodGet, "/api/tasks/analytics", nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
-// CancelTask cancels a running task
-func (c *Client) CancelTask(ctx context.Context, taskID string) (*Response[map[string]string], error) {
-	var result map[string]string
-	path := fmt.Sprintf("/tasks/%s/cancel", taskID)
-	err := c.makeRequest(ctx, http.MethodPost, path, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]string]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// AnalyzeCode performs AI-powered code analysis
-func (c *Client) AnalyzeCode(ctx context.Context, req CodeAnalysisRequest) (*Response[map[string]interface{}], error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodPost, "/ai/analyze", req, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]interface{}]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// PredictPerformance predicts performance metrics
-func (c *Client) PredictPerformance(ctx context.Context, metrics map[string]interface{}) (*Response[map[string]interface{}], error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodPost, "/ai/predict", metrics, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]interface{}]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// GenerateCode generates code from description
-func (c *Client) GenerateCode(ctx context.Context, req CodeGenerationRequest) (*Response[map[string]interface{}], error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodPost, "/ai/generate", req, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]interface{}]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// RegisterWebhook registers a webhook for events
-func (c *Client) RegisterWebhook(ctx context.Context, registration WebhookRegistration) (*Response[map[string]interface{}], error) {
-	var result map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodPost, "/webhooks", registration, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]interface{}]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   201,
-		ResponseTime: 0,
-	}, nil
-}
-
-// ListWebhooks lists registered webhooks
-func (c *Client) ListWebhooks(ctx context.Context) (*Response[[]map[string]interface{}], error) {
-	var result []map[string]interface{}
-	err := c.makeRequest(ctx, http.MethodGet, "/webhooks", nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[[]map[string]interface{}]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// DeleteWebhook deletes a webhook
-func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) (*Response[map[string]string], error) {
-	var result map[string]string
-	path := fmt.Sprintf("/webhooks/%s", webhookID)
-	err := c.makeRequest(ctx, http.MethodDelete, path, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]string]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// ListPlugins lists available plugins
-func (c *Client) ListPlugins(ctx context.Context) (*Response[[]PluginInfo], error) {
-	var result []PluginInfo
-	err := c.makeRequest(ctx, http.MethodGet, "/plugins", nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[[]PluginInfo]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// GetPluginInfo gets information about a specific plugin
-func (c *Client) GetPluginInfo(ctx context.Context, pluginName string) (*Response[PluginInfo], error) {
-	var result PluginInfo
-	path := fmt.Sprintf("/plugins/%s", pluginName)
-	err := c.makeRequest(ctx, http.MethodGet, path, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[PluginInfo]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// InstallPlugin installs a plugin
-func (c *Client) InstallPlugin(ctx context.Context, pluginName string, config map[string]interface{}) (*Response[PluginInfo], error) {
-	body := map[string]interface{}{
-		"name":   pluginName,
-		"config": config,
-	}
-	var result PluginInfo
-	err := c.makeRequest(ctx, http.MethodPost, "/plugins/install", body, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[PluginInfo]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
-
-// UninstallPlugin uninstalls a plugin
-func (c *Client) UninstallPlugin(ctx context.Context, pluginName string) (*Response[map[string]string], error) {
-	var result map[string]string
-	path := fmt.Sprintf("/plugins/%s/uninstall", pluginName)
-	err := c.makeRequest(ctx, http.MethodPost, path, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &Response[map[string]string]{
-		Success:      true,
-		Data:         result,
-		StatusCode:   200,
-		ResponseTime: 0,
-	}, nil
-}
\ No newline at end of file
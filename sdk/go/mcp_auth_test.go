@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAuthTestServer(t *testing.T, expire time.Time) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var loginCount int32
+	var registerCount int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/watchers", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&registerCount, 1) > 1 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/v1/watchers/login", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&loginCount, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(loginResponse{
+			Token:  "test-token",
+			Expire: expire.Format(time.RFC3339),
+		})
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "unauthorized"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":   true,
+			"data": map[string]interface{}{"status": "healthy"},
+		})
+	})
+
+	return httptest.NewServer(mux), &loginCount
+}
+
+func TestClientLoginOnFirstRequest(t *testing.T) {
+	server, loginCount := setupAuthTestServer(t, time.Now().Add(time.Hour))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		MachineID: "machine1",
+		Password:  "secret",
+	})
+
+	status, err := client.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", status.Status)
+	assert.EqualValues(t, 1, atomic.LoadInt32(loginCount))
+}
+
+func TestClientRefreshesExpiringToken(t *testing.T) {
+	server, loginCount := setupAuthTestServer(t, time.Now().Add(time.Second))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		MachineID:        "machine1",
+		Password:         "secret",
+		TokenRefreshSkew: time.Minute,
+	})
+
+	_, err := client.GetStatus(context.Background())
+	require.NoError(t, err)
+	_, err = client.GetStatus(context.Background())
+	require.NoError(t, err)
+
+	// Both calls happen while the token is within the refresh skew, so each
+	// should trigger its own login.
+	assert.EqualValues(t, 2, atomic.LoadInt32(loginCount))
+}
+
+func TestClientRegisterIsIdempotent(t *testing.T) {
+	server, _ := setupAuthTestServer(t, time.Now().Add(time.Hour))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{})
+
+	err := client.Register(context.Background(), "machine1", "secret")
+	require.NoError(t, err)
+
+	// Second registration collides (403 "already exists") and should still
+	// be reported as success.
+	err = client.Register(context.Background(), "machine1", "secret")
+	require.NoError(t, err)
+}
+
+// setupRevokingAuthTestServer behaves like setupAuthTestServer, except the
+// token /status accepts is rotated server-side after revokeAfter successful
+// requests *for that login's token*, so a client request can hit a genuine
+// 401 despite a locally "fresh" token - simulating the server revoking a
+// token out from under it. servedThisGeneration is reset on every login, so
+// revocation gates each generation's token independently rather than
+// latching forever after the first login.
+func setupRevokingAuthTestServer(t *testing.T, revokeAfter int32) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var loginCount int32
+
+	var mu sync.Mutex
+	var servedThisGeneration int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/watchers/login", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&loginCount, 1)
+
+		mu.Lock()
+		servedThisGeneration = 0
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(loginResponse{
+			Token:  fmt.Sprintf("token-%d", n),
+			Expire: time.Now().Add(time.Hour).Format(time.RFC3339),
+		})
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		wantToken := fmt.Sprintf("Bearer token-%d", atomic.LoadInt32(&loginCount))
+
+		mu.Lock()
+		allowed := r.Header.Get("Authorization") == wantToken && servedThisGeneration < revokeAfter
+		if allowed {
+			servedThisGeneration++
+		}
+		mu.Unlock()
+
+		if !allowed {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "unauthorized"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":   true,
+			"data": map[string]interface{}{"status": "healthy"},
+		})
+	})
+
+	return httptest.NewServer(mux), &loginCount
+}
+
+func TestClientRelogsInOnceAfterServerRevokesTokenMidFlight(t *testing.T) {
+	// The server accepts exactly one request per login before revoking the
+	// token, so the second GetStatus call always has to re-login despite
+	// the client believing its cached token is still fresh.
+	server, loginCount := setupRevokingAuthTestServer(t, 1)
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		MachineID: "machine1",
+		Password:  "secret",
+	})
+
+	status, err := client.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", status.Status)
+	assert.EqualValues(t, 1, atomic.LoadInt32(loginCount))
+
+	// The server has now revoked token-1; this call must hit a 401, force a
+	// re-login, and retry exactly once rather than failing outright.
+	status, err = client.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", status.Status)
+	assert.EqualValues(t, 2, atomic.LoadInt32(loginCount))
+}
+
+func TestClientConcurrentRequestsShareOneLogin(t *testing.T) {
+	server, loginCount := setupAuthTestServer(t, time.Now().Add(time.Hour))
+	defer server.Close()
+
+	client := NewClient(server.URL, &ClientOptions{
+		MachineID: "machine1",
+		Password:  "secret",
+	})
+
+	const n = 10
+	done := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := client.GetStatus(context.Background())
+			done <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-done)
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(loginCount))
+}
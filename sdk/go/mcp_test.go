@@ -99,7 +99,7 @@ func TestListControllers(t *testing.T) {
 
 	client := NewClient(server.URL, nil)
 
-	controllers, err := client.ListControllers(context.Background())
+	controllers, err := client.Agents.List(context.Background())
 	require.NoError(t, err)
 	assert.NotNil(t, controllers)
 	// The response should contain agent data
@@ -124,7 +124,7 @@ func TestListTasks(t *testing.T) {
 
 	client := NewClient(server.URL, nil)
 
-	tasks, err := client.ListTasks(context.Background(), "", "")
+	tasks, err := client.Tasks.List(context.Background(), "", "")
 	require.NoError(t, err)
 	assert.NotNil(t, tasks)
 	assert.Equal(t, float64(34), (*tasks)["completed_tasks"])
@@ -172,98 +172,3 @@ func TestClientWithCustomOptions(t *testing.T) {
 	assert.Equal(t, "value", customHeader)
 	assert.Equal(t, "application/json", contentType)
 }
-
-func TestTaskSubmission(t *testing.T) {
-	task := TaskSubmission{
-		Type:       "code_analysis",
-		Target:     "src/main.go",
-		Priority:   "high",
-		Parameters: map[string]interface{}{
-			"includeMetrics": true,
-			"outputFormat":   "json",
-		},
-	}
-
-	data, err := json.Marshal(task)
-	require.NoError(t, err)
-
-	var unmarshaled TaskSubmission
-	err = json.Unmarshal(data, &unmarshaled)
-	require.NoError(t, err)
-
-	assert.Equal(t, task.Type, unmarshaled.Type)
-	assert.Equal(t, task.Target, unmarshaled.Target)
-	assert.Equal(t, task.Priority, unmarshaled.Priority)
-	assert.Equal(t, task.Parameters["includeMetrics"], unmarshaled.Parameters["includeMetrics"])
-}
-
-func TestCodeAnalysisRequest(t *testing.T) {
-	req := CodeAnalysisRequest{
-		Code:     "func add(a, b int) int { return a + b }",
-		Language: "go",
-		Options: map[string]bool{
-			"includeSuggestions": true,
-			"includeMetrics":    true,
-		},
-		Context: map[string]string{
-			"framework": "gin",
-			"version":   "1.9",
-		},
-	}
-
-	data, err := json.Marshal(req)
-	require.NoError(t, err)
-
-	var unmarshaled CodeAnalysisRequest
-	err = json.Unmarshal(data, &unmarshaled)
-	require.NoError(t, err)
-
-	assert.Equal(t, req.Code, unmarshaled.Code)
-	assert.Equal(t, req.Language, unmarshaled.Language)
-	assert.Equal(t, req.Options["includeSuggestions"], unmarshaled.Options["includeSuggestions"])
-	assert.Equal(t, req.Context["framework"], unmarshaled.Context["framework"])
-}
-
-func TestCodeGenerationRequest(t *testing.T) {
-	req := CodeGenerationRequest{
-		Description: "Create a REST API handler for user authentication",
-		Language:    "go",
-		Context:     "Gin web framework application",
-		Constraints: []string{
-			"Use proper error handling",
-			"Include input validation",
-			"Return JSON responses",
-		},
-	}
-
-	data, err := json.Marshal(req)
-	require.NoError(t, err)
-
-	var unmarshaled CodeGenerationRequest
-	err = json.Unmarshal(data, &unmarshaled)
-	require.NoError(t, err)
-
-	assert.Equal(t, req.Description, unmarshaled.Description)
-	assert.Equal(t, req.Language, unmarshaled.Language)
-	assert.Equal(t, req.Context, unmarshaled.Context)
-	assert.Equal(t, req.Constraints, unmarshaled.Constraints)
-}
-
-func TestWebhookRegistration(t *testing.T) {
-	reg := WebhookRegistration{
-		URL:    "https://my-app.com/webhooks/mcp",
-		Events: []string{"task.completed", "task.failed"},
-		Secret: "webhook-secret-key",
-	}
-
-	data, err := json.Marshal(reg)
-	require.NoError(t, err)
-
-	var unmarshaled WebhookRegistration
-	err = json.Unmarshal(data, &unmarshaled)
-	require.NoError(t, err)
-
-	assert.Equal(t, reg.URL, unmarshaled.URL)
-	assert.Equal(t, reg.Events, unmarshaled.Events)
-	assert.Equal(t, reg.Secret, unmarshaled.Secret)
-}
\ No newline at end of file
@@ -0,0 +1,96 @@
+// Package metrics provides optional Prometheus instrumentation for the MCP
+// client. It is a separate package so that importing the SDK never pulls in
+// client_golang for callers who don't want it; wire it in by setting
+// ClientOptions.Metrics.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsCollector records Prometheus metrics for every outbound MCP client
+// call. A nil *MetricsCollector is safe to call methods on and simply
+// records nothing, so ClientOptions.Metrics can be left unset.
+type MetricsCollector struct {
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	retriesTotal    *prometheus.CounterVec
+	inflight        *prometheus.GaugeVec
+}
+
+// NewMetricsCollector creates a MetricsCollector and registers its metrics
+// against reg.
+func NewMetricsCollector(reg prometheus.Registerer) *MetricsCollector {
+	c := &MetricsCollector{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_client_requests_total",
+			Help: "Total number of MCP client requests, by method, path, and outcome status.",
+		}, []string{"method", "path", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_client_request_duration_seconds",
+			Help:    "Duration of MCP client requests in seconds, by method and path.",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		}, []string{"method", "path"}),
+		retriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_client_retries_total",
+			Help: "Total number of MCP client requests retried after a 401, by path.",
+		}, []string{"path"}),
+		inflight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcp_client_inflight_requests",
+			Help: "Number of MCP client requests currently in flight, by path.",
+		}, []string{"path"}),
+	}
+
+	reg.MustRegister(c.requestsTotal, c.requestDuration, c.retriesTotal, c.inflight)
+	return c
+}
+
+var (
+	defaultCollectorOnce sync.Once
+	defaultCollector     *MetricsCollector
+)
+
+// DefaultCollector returns a MetricsCollector registered against
+// prometheus.DefaultRegisterer, for services that already expose it on a
+// scrape endpoint and just want the client's metrics included for free.
+// Registration happens lazily, on the first call, so merely importing this
+// package (as mcp does, for ClientOptions.Metrics' type) never touches
+// prometheus.DefaultRegisterer on behalf of callers who never call this or
+// set ClientOptions.Metrics.
+func DefaultCollector() *MetricsCollector {
+	defaultCollectorOnce.Do(func() {
+		defaultCollector = NewMetricsCollector(prometheus.DefaultRegisterer)
+	})
+	return defaultCollector
+}
+
+// ObserveStart records the start of an outbound request, incrementing the
+// inflight gauge for path. The returned func must be called exactly once
+// when the request completes, with the outcome status ("ok", "mcp_error",
+// or "connection_error"), to observe its duration and increment the
+// terminal counter.
+func (c *MetricsCollector) ObserveStart(method, path string) func(status string) {
+	if c == nil {
+		return func(string) {}
+	}
+
+	c.inflight.WithLabelValues(path).Inc()
+	start := time.Now()
+
+	return func(status string) {
+		c.inflight.WithLabelValues(path).Dec()
+		c.requestDuration.WithLabelValues(method, path).Observe(time.Since(start).Seconds())
+		c.requestsTotal.WithLabelValues(method, path, status).Inc()
+	}
+}
+
+// RecordRetry increments the retry counter for path.
+func (c *MetricsCollector) RecordRetry(path string) {
+	if c == nil {
+		return
+	}
+	c.retriesTotal.WithLabelValues(path).Inc()
+}
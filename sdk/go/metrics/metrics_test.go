@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func counterValue(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+	var pb dto.Metric
+	require.NoError(t, m.Write(&pb))
+	return pb.GetCounter().GetValue()
+}
+
+func TestObserveStartRecordsOkOutcome(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c := NewMetricsCollector(reg)
+
+	finish := c.ObserveStart("GET", "/status")
+	finish("ok")
+
+	assert.Equal(t, float64(1), counterValue(t, c.requestsTotal.WithLabelValues("GET", "/status", "ok")))
+	assert.Equal(t, float64(0), counterValue(t, c.requestsTotal.WithLabelValues("GET", "/status", "mcp_error")))
+}
+
+func TestRecordRetryIncrementsCounter(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c := NewMetricsCollector(reg)
+
+	c.RecordRetry("/run")
+	c.RecordRetry("/run")
+
+	assert.Equal(t, float64(2), counterValue(t, c.retriesTotal.WithLabelValues("/run")))
+}
+
+func TestNilCollectorMethodsAreNoOps(t *testing.T) {
+	var c *MetricsCollector
+
+	finish := c.ObserveStart("GET", "/status")
+	assert.NotPanics(t, func() { finish("ok") })
+	assert.NotPanics(t, func() { c.RecordRetry("/run") })
+}
+
+func TestDefaultCollectorRegistersAgainstDefaultRegistererOnlyWhenCalled(t *testing.T) {
+	assert.Nil(t, defaultCollector, "importing the package must not register metrics before DefaultCollector is called")
+
+	c := DefaultCollector()
+	require.NotNil(t, c)
+	assert.Same(t, c, DefaultCollector(), "DefaultCollector must register exactly once and return the same instance")
+}
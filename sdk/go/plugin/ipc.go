@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// rpcMessage is the JSON-RPC-ish envelope exchanged over the plugin's
+// stdin/stdout pipe. Exactly one of Params (request) or Result/Error
+// (response) is meaningful for a given message; Method is empty on
+// responses.
+type rpcMessage struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// frameWriter serializes rpcMessages as length-prefixed JSON: a 4-byte
+// big-endian length followed by that many bytes of JSON. Framing (rather
+// than newline-delimiting) means a plugin's payload can itself contain
+// newlines without desyncing the stream.
+type frameWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newFrameWriter(w io.Writer) *frameWriter {
+	return &frameWriter{w: w}
+}
+
+func (f *frameWriter) write(msg rpcMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("plugin: failed to marshal rpc message: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(body)))
+	if _, err := f.w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	_, err = f.w.Write(body)
+	return err
+}
+
+// frameReader is the read-side counterpart of frameWriter.
+type frameReader struct {
+	r *bufio.Reader
+}
+
+func newFrameReader(r io.Reader) *frameReader {
+	return &frameReader{r: bufio.NewReader(r)}
+}
+
+func (f *frameReader) read() (rpcMessage, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(f.r, lenBuf[:]); err != nil {
+		return rpcMessage{}, err
+	}
+	size := binary.BigEndian.Uint32(lenBuf[:])
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(f.r, body); err != nil {
+		return rpcMessage{}, err
+	}
+
+	var msg rpcMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return rpcMessage{}, fmt.Errorf("plugin: failed to unmarshal rpc message: %w", err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameWriterReaderRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := newFrameWriter(&buf)
+	r := newFrameReader(&buf)
+
+	want := rpcMessage{ID: 42, Method: "ExecuteTask", Params: []byte(`{"type":"code_analysis"}`)}
+	require.NoError(t, w.write(want))
+
+	got, err := r.read()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestFrameWriterReaderMultipleMessages(t *testing.T) {
+	var buf bytes.Buffer
+	w := newFrameWriter(&buf)
+	r := newFrameReader(&buf)
+
+	for i := uint64(0); i < 3; i++ {
+		require.NoError(t, w.write(rpcMessage{ID: i, Result: []byte(`{}`)}))
+	}
+
+	for i := uint64(0); i < 3; i++ {
+		msg, err := r.read()
+		require.NoError(t, err)
+		assert.Equal(t, i, msg.ID)
+	}
+}
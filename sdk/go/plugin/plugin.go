@@ -0,0 +1,124 @@
+// Package plugin lets SDK users package MCP plugins as standalone Go
+// binaries and run them as supervised child processes, modeled on
+// Mattermost's rpcplugin design: the plugin speaks a small length-prefixed
+// JSON-RPC protocol over its own stdin/stdout, and the host (PluginSupervisor)
+// owns the process lifecycle.
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+)
+
+const (
+	methodExecuteTask = "ExecuteTask"
+	methodOnEvent     = "OnEvent"
+	methodDeactivate  = "Deactivate"
+)
+
+// TaskResult is returned by Hooks.ExecuteTask.
+type TaskResult struct {
+	Output map[string]interface{} `json:"output,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// Event is delivered to Hooks.OnEvent for out-of-band notifications (agent
+// status changes, system alerts, ...) the host forwards to the plugin.
+type Event struct {
+	Type    string                 `json:"type"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// Hooks is implemented by a plugin binary. OnActivate runs once when the
+// supervisor has finished launching the process; OnDeactivate runs before
+// the process exits (including on a supervisor-initiated shutdown).
+type Hooks interface {
+	OnActivate() error
+	OnDeactivate() error
+	ExecuteTask(task tasks.Submission) (TaskResult, error)
+	OnEvent(event Event) error
+}
+
+// Serve runs hooks as an RPC plugin, reading requests from stdin and writing
+// responses to stdout until stdin is closed by the host. It blocks until the
+// host disconnects or hooks.OnDeactivate returns. Call it from a plugin
+// binary's main function:
+//
+//	func main() {
+//	    plugin.Serve(&myHooks{})
+//	}
+func Serve(hooks Hooks) error {
+	if err := hooks.OnActivate(); err != nil {
+		return fmt.Errorf("plugin: OnActivate failed: %w", err)
+	}
+
+	reader := newFrameReader(os.Stdin)
+	writer := newFrameWriter(os.Stdout)
+
+	for {
+		req, err := reader.read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("plugin: failed to read request: %w", err)
+		}
+
+		if req.Method == methodDeactivate {
+			resp := rpcMessage{ID: req.ID}
+			_ = writer.write(resp)
+			break
+		}
+
+		resp := dispatch(hooks, req)
+		if err := writer.write(resp); err != nil {
+			return fmt.Errorf("plugin: failed to write response: %w", err)
+		}
+	}
+
+	return hooks.OnDeactivate()
+}
+
+func dispatch(hooks Hooks, req rpcMessage) rpcMessage {
+	switch req.Method {
+	case methodExecuteTask:
+		var task tasks.Submission
+		if err := json.Unmarshal(req.Params, &task); err != nil {
+			return errorResponse(req.ID, err)
+		}
+		result, err := hooks.ExecuteTask(task)
+		if err != nil {
+			return errorResponse(req.ID, err)
+		}
+		return resultResponse(req.ID, result)
+
+	case methodOnEvent:
+		var event Event
+		if err := json.Unmarshal(req.Params, &event); err != nil {
+			return errorResponse(req.ID, err)
+		}
+		if err := hooks.OnEvent(event); err != nil {
+			return errorResponse(req.ID, err)
+		}
+		return resultResponse(req.ID, struct{}{})
+
+	default:
+		return errorResponse(req.ID, fmt.Errorf("plugin: unknown method %q", req.Method))
+	}
+}
+
+func resultResponse(id uint64, v interface{}) rpcMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errorResponse(id, err)
+	}
+	return rpcMessage{ID: id, Result: b}
+}
+
+func errorResponse(id uint64, err error) rpcMessage {
+	return rpcMessage{ID: id, Error: err.Error()}
+}
@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubHooks struct {
+	executeTask func(tasks.Submission) (TaskResult, error)
+	onEvent     func(Event) error
+}
+
+func (h *stubHooks) OnActivate() error   { return nil }
+func (h *stubHooks) OnDeactivate() error { return nil }
+func (h *stubHooks) ExecuteTask(task tasks.Submission) (TaskResult, error) {
+	return h.executeTask(task)
+}
+func (h *stubHooks) OnEvent(event Event) error { return h.onEvent(event) }
+
+func TestDispatchExecuteTask(t *testing.T) {
+	hooks := &stubHooks{
+		executeTask: func(task tasks.Submission) (TaskResult, error) {
+			return TaskResult{Output: map[string]interface{}{"echo": task.Type}}, nil
+		},
+	}
+
+	params, _ := json.Marshal(tasks.Submission{Type: "code_analysis"})
+	resp := dispatch(hooks, rpcMessage{ID: 1, Method: methodExecuteTask, Params: params})
+
+	require.Empty(t, resp.Error)
+	var result TaskResult
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+	assert.Equal(t, "code_analysis", result.Output["echo"])
+}
+
+func TestDispatchOnEvent(t *testing.T) {
+	var received Event
+	hooks := &stubHooks{
+		onEvent: func(e Event) error {
+			received = e
+			return nil
+		},
+	}
+
+	params, _ := json.Marshal(Event{Type: "system.alert"})
+	resp := dispatch(hooks, rpcMessage{ID: 2, Method: methodOnEvent, Params: params})
+
+	require.Empty(t, resp.Error)
+	assert.Equal(t, "system.alert", received.Type)
+}
+
+func TestDispatchUnknownMethod(t *testing.T) {
+	hooks := &stubHooks{}
+	resp := dispatch(hooks, rpcMessage{ID: 3, Method: "Bogus"})
+	assert.NotEmpty(t, resp.Error)
+}
@@ -0,0 +1,59 @@
+// Package plugintest provides a mock plugin.Hooks implementation so SDK
+// users can unit-test plugin logic without spawning a child process.
+package plugintest
+
+import (
+	"github.com/dboone323/tools-automation/sdk/go/plugin"
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+)
+
+// Hooks is a plugin.Hooks implementation whose behavior is configured by
+// setting its function fields directly. Unset fields are no-ops that
+// return a zero value and nil error, so tests only need to provide the
+// hooks they care about.
+type Hooks struct {
+	OnActivateFunc   func() error
+	OnDeactivateFunc func() error
+	ExecuteTaskFunc  func(task tasks.Submission) (plugin.TaskResult, error)
+	OnEventFunc      func(event plugin.Event) error
+
+	// Calls records every hook invocation, in order, for assertions like
+	// "ExecuteTask was called exactly once".
+	Calls []string
+}
+
+// OnActivate implements plugin.Hooks.
+func (h *Hooks) OnActivate() error {
+	h.Calls = append(h.Calls, "OnActivate")
+	if h.OnActivateFunc != nil {
+		return h.OnActivateFunc()
+	}
+	return nil
+}
+
+// OnDeactivate implements plugin.Hooks.
+func (h *Hooks) OnDeactivate() error {
+	h.Calls = append(h.Calls, "OnDeactivate")
+	if h.OnDeactivateFunc != nil {
+		return h.OnDeactivateFunc()
+	}
+	return nil
+}
+
+// ExecuteTask implements plugin.Hooks.
+func (h *Hooks) ExecuteTask(task tasks.Submission) (plugin.TaskResult, error) {
+	h.Calls = append(h.Calls, "ExecuteTask")
+	if h.ExecuteTaskFunc != nil {
+		return h.ExecuteTaskFunc(task)
+	}
+	return plugin.TaskResult{}, nil
+}
+
+// OnEvent implements plugin.Hooks.
+func (h *Hooks) OnEvent(event plugin.Event) error {
+	h.Calls = append(h.Calls, "OnEvent")
+	if h.OnEventFunc != nil {
+		return h.OnEventFunc(event)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SupervisorOptions configures a PluginSupervisor.
+type SupervisorOptions struct {
+	// PluginDir is the directory plugin executables must live in. Launch
+	// rejects any plugin name that would resolve (after following
+	// symlinks) outside of it.
+	PluginDir string
+	// MaxRestartBackoff caps the exponential backoff applied between
+	// restart attempts after a plugin crashes. Defaults to 30s.
+	MaxRestartBackoff time.Duration
+	// CallTimeout is the default deadline applied to Call when its ctx
+	// has none. Defaults to 30s.
+	CallTimeout time.Duration
+}
+
+// PluginSupervisor launches plugin binaries as child processes, speaks the
+// length-prefixed JSON-RPC protocol defined by this package over their
+// stdin/stdout, and restarts them with exponential backoff if they exit
+// unexpectedly.
+type PluginSupervisor struct {
+	opts SupervisorOptions
+
+	mu        sync.Mutex
+	processes map[string]*pluginProcess
+}
+
+// NewPluginSupervisor creates a supervisor rooted at opts.PluginDir.
+func NewPluginSupervisor(opts SupervisorOptions) *PluginSupervisor {
+	if opts.MaxRestartBackoff == 0 {
+		opts.MaxRestartBackoff = 30 * time.Second
+	}
+	if opts.CallTimeout == 0 {
+		opts.CallTimeout = 30 * time.Second
+	}
+	return &PluginSupervisor{
+		opts:      opts,
+		processes: make(map[string]*pluginProcess),
+	}
+}
+
+// Launch starts the plugin executable named name (relative to PluginDir)
+// and waits for it to become ready to accept calls. If the plugin later
+// crashes, the supervisor restarts it automatically.
+func (s *PluginSupervisor) Launch(name string) error {
+	path, err := s.resolvePluginPath(name)
+	if err != nil {
+		return err
+	}
+
+	proc, err := startPluginProcess(path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.processes[name] = proc
+	s.mu.Unlock()
+
+	go s.superviseRestarts(name, path)
+	return nil
+}
+
+// resolvePluginPath validates that name, once joined with PluginDir and
+// resolved through any symlinks, still lives inside PluginDir. This rejects
+// both "../escape" traversal and a symlink planted inside PluginDir that
+// points elsewhere.
+func (s *PluginSupervisor) resolvePluginPath(name string) (string, error) {
+	if strings.Contains(name, "..") {
+		return "", fmt.Errorf("plugin: invalid plugin name %q", name)
+	}
+
+	root, err := filepath.Abs(s.opts.PluginDir)
+	if err != nil {
+		return "", fmt.Errorf("plugin: failed to resolve plugin dir: %w", err)
+	}
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("plugin: failed to resolve plugin dir: %w", err)
+	}
+
+	candidate := filepath.Join(root, name)
+	resolved, err := filepath.EvalSymlinks(candidate)
+	if err != nil {
+		return "", fmt.Errorf("plugin: failed to resolve plugin path: %w", err)
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("plugin: plugin %q escapes plugin directory", name)
+	}
+
+	return resolved, nil
+}
+
+// superviseRestarts waits for the running process to exit and relaunches it
+// with exponential backoff, until Stop is called for name.
+func (s *PluginSupervisor) superviseRestarts(name, path string) {
+	backoff := 500 * time.Millisecond
+
+	for {
+		s.mu.Lock()
+		proc, ok := s.processes[name]
+		s.mu.Unlock()
+		if !ok {
+			return
+		}
+
+		<-proc.exited
+		if atomic.LoadInt32(&proc.stopped) == 1 {
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > s.opts.MaxRestartBackoff {
+			backoff = s.opts.MaxRestartBackoff
+		}
+
+		next, err := startPluginProcess(path)
+		if err != nil {
+			// Couldn't even start it this round; keep backing off.
+			continue
+		}
+
+		s.mu.Lock()
+		if s.processes[name] != proc {
+			// Stop was called for name while we were backing off: it already
+			// removed (or replaced) this entry, so reinstating next here
+			// would resurrect a plugin the caller explicitly stopped.
+			s.mu.Unlock()
+			_ = next.cmd.Process.Kill()
+			return
+		}
+		s.processes[name] = next
+		s.mu.Unlock()
+		backoff = 500 * time.Millisecond
+	}
+}
+
+// Call invokes method on the plugin named pluginName with args and decodes
+// its result into result (which may be nil to discard it).
+func (s *PluginSupervisor) Call(ctx context.Context, pluginName, method string, args interface{}, result interface{}) error {
+	s.mu.Lock()
+	proc, ok := s.processes[pluginName]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("plugin: no running plugin named %q", pluginName)
+	}
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.CallTimeout)
+		defer cancel()
+	}
+
+	return proc.call(ctx, method, args, result)
+}
+
+// Stop terminates the plugin named name and prevents it from being
+// restarted.
+func (s *PluginSupervisor) Stop(name string) error {
+	s.mu.Lock()
+	proc, ok := s.processes[name]
+	delete(s.processes, name)
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("plugin: no running plugin named %q", name)
+	}
+	return proc.stop()
+}
+
+// pluginProcess wraps one running plugin subprocess and multiplexes
+// concurrent Call requests over its single stdin/stdout pipe pair.
+type pluginProcess struct {
+	cmd    *exec.Cmd
+	writer *frameWriter
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan rpcMessage
+
+	exited  chan struct{}
+	stopped int32
+}
+
+func startPluginProcess(path string) (*pluginProcess, error) {
+	cmd := exec.Command(path)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("plugin: failed to open stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("plugin: failed to open stdout pipe: %w", err)
+	}
+	cmd.Stderr = nil // inherited by default; callers can set a logger-backed writer via cmd.Stderr before Start in a future revision
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("plugin: failed to start plugin: %w", err)
+	}
+
+	proc := &pluginProcess{
+		cmd:     cmd,
+		writer:  newFrameWriter(stdin),
+		pending: make(map[uint64]chan rpcMessage),
+		exited:  make(chan struct{}),
+	}
+
+	reader := newFrameReader(stdout)
+	go proc.readLoop(reader)
+	go func() {
+		_ = cmd.Wait()
+		close(proc.exited)
+	}()
+
+	return proc, nil
+}
+
+func (p *pluginProcess) readLoop(reader *frameReader) {
+	for {
+		msg, err := reader.read()
+		if err != nil {
+			return
+		}
+
+		p.mu.Lock()
+		ch, ok := p.pending[msg.ID]
+		if ok {
+			delete(p.pending, msg.ID)
+		}
+		p.mu.Unlock()
+
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+func (p *pluginProcess) call(ctx context.Context, method string, args interface{}, result interface{}) error {
+	params, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("plugin: failed to marshal args: %w", err)
+	}
+
+	p.mu.Lock()
+	p.nextID++
+	id := p.nextID
+	respCh := make(chan rpcMessage, 1)
+	p.pending[id] = respCh
+	p.mu.Unlock()
+
+	if err := p.writer.write(rpcMessage{ID: id, Method: method, Params: params}); err != nil {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return fmt.Errorf("plugin: failed to send request: %w", err)
+	}
+
+	select {
+	case resp := <-respCh:
+		if resp.Error != "" {
+			return fmt.Errorf("plugin: %s", resp.Error)
+		}
+		if result != nil && len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("plugin: failed to unmarshal result: %w", err)
+			}
+		}
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return ctx.Err()
+	case <-p.exited:
+		return fmt.Errorf("plugin: process exited before responding")
+	}
+}
+
+func (p *pluginProcess) stop() error {
+	atomic.StoreInt32(&p.stopped, 1)
+	if err := p.writer.write(rpcMessage{Method: methodDeactivate}); err != nil {
+		_ = p.cmd.Process.Kill()
+		return nil
+	}
+
+	select {
+	case <-p.exited:
+	case <-time.After(5 * time.Second):
+		_ = p.cmd.Process.Kill()
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePluginPathRejectsTraversal(t *testing.T) {
+	dir := t.TempDir()
+	s := NewPluginSupervisor(SupervisorOptions{PluginDir: dir})
+
+	_, err := s.resolvePluginPath("../../etc/passwd")
+	assert.Error(t, err)
+}
+
+func TestResolvePluginPathRejectsSymlinkEscape(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	target := filepath.Join(outside, "evil")
+	require.NoError(t, os.WriteFile(target, []byte("#!/bin/sh\n"), 0o755))
+
+	link := filepath.Join(dir, "plugin")
+	require.NoError(t, os.Symlink(target, link))
+
+	s := NewPluginSupervisor(SupervisorOptions{PluginDir: dir})
+	_, err := s.resolvePluginPath("plugin")
+	assert.Error(t, err)
+}
+
+func TestResolvePluginPathAcceptsInDirBinary(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "plugin")
+	require.NoError(t, os.WriteFile(binPath, []byte("#!/bin/sh\n"), 0o755))
+
+	s := NewPluginSupervisor(SupervisorOptions{PluginDir: dir})
+	resolved, err := s.resolvePluginPath("plugin")
+	require.NoError(t, err)
+	assert.Equal(t, binPath, resolved)
+}
+
+// TestStopDuringRestartBackoffPreventsResurrection exercises the race where
+// a plugin crashes and Stop is called while superviseRestarts is still
+// sleeping out its backoff: Stop must win, and the supervisor must not
+// reinstate a replacement process under name afterwards.
+func TestStopDuringRestartBackoffPreventsResurrection(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "plugin")
+	require.NoError(t, os.WriteFile(binPath, []byte("#!/bin/sh\nsleep 100\n"), 0o755))
+
+	s := NewPluginSupervisor(SupervisorOptions{PluginDir: dir})
+	require.NoError(t, s.Launch("plugin"))
+
+	s.mu.Lock()
+	crashed := s.processes["plugin"]
+	s.mu.Unlock()
+	require.NoError(t, crashed.cmd.Process.Kill())
+
+	// Give superviseRestarts a moment to observe the crash and pass its
+	// "am I stopped" check, then call Stop while it's still sleeping out
+	// the (fixed, 500ms) initial backoff.
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, s.Stop("plugin"))
+
+	// Wait past the backoff window plus margin for the restart attempt to
+	// run and observe that Stop already won.
+	time.Sleep(700 * time.Millisecond)
+
+	s.mu.Lock()
+	_, ok := s.processes["plugin"]
+	s.mu.Unlock()
+	assert.False(t, ok, "Stop during restart backoff must not be overridden by a resurrected process")
+}
@@ -0,0 +1,79 @@
+// Package plugins provides the MCP client's plugin resource operations:
+// listing, inspecting, installing, and uninstalling plugins known to the
+// server. It is unrelated to the sibling plugin package, which hosts
+// out-of-process plugins over a local IPC connection. Construct a Service
+// with New, sharing the transport.Client backing *mcp.Client; mcp.Client.Plugins
+// embeds one for convenient access as c.Plugins.List(ctx).
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// Info represents plugin information.
+type Info struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Description  string   `json:"description,omitempty"`
+	Capabilities []string `json:"capabilities"`
+	Status       string   `json:"status"`
+	InstalledAt  string   `json:"installedAt,omitempty"`
+}
+
+// Service provides plugin resource operations against a shared
+// transport.Client.
+type Service struct {
+	client transport.Client
+}
+
+// New creates a Service backed by client.
+func New(client transport.Client) *Service {
+	return &Service{client: client}
+}
+
+// List lists available plugins.
+func (s *Service) List(ctx context.Context, opts ...transport.CallOption) ([]Info, error) {
+	var result []Info
+	if err := s.client.Do(ctx, http.MethodGet, "/plugins", nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get gets information about a specific plugin.
+func (s *Service) Get(ctx context.Context, name string, opts ...transport.CallOption) (*Info, error) {
+	var result Info
+	path := fmt.Sprintf("/plugins/%s", name)
+	if err := s.client.Do(ctx, http.MethodGet, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Install installs a plugin. Pass transport.WithIdempotencyKey so a retried
+// request can't install the plugin twice.
+func (s *Service) Install(ctx context.Context, name string, config map[string]interface{}, opts ...transport.CallOption) (*Info, error) {
+	body := map[string]interface{}{
+		"name":   name,
+		"config": config,
+	}
+	var result Info
+	if err := s.client.Do(ctx, http.MethodPost, "/plugins/install", body, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Uninstall uninstalls a plugin.
+func (s *Service) Uninstall(ctx context.Context, name string, opts ...transport.CallOption) (*map[string]string, error) {
+	var result map[string]string
+	path := fmt.Sprintf("/plugins/%s/uninstall", name)
+	if err := s.client.Do(ctx, http.MethodPost, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
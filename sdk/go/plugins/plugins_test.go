@@ -0,0 +1,87 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+type fakeClient struct {
+	method, path string
+	body         interface{}
+	resp         interface{}
+	err          error
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, path string, body, result interface{}, opts ...transport.CallOption) error {
+	f.method, f.path, f.body = method, path, body
+	if f.err != nil {
+		return f.err
+	}
+	if f.resp != nil && result != nil {
+		b, _ := json.Marshal(f.resp)
+		return json.Unmarshal(b, result)
+	}
+	return nil
+}
+
+func TestServiceMethodsIssueExpectedRequests(t *testing.T) {
+	cases := []struct {
+		name       string
+		call       func(s *Service, c *fakeClient) (interface{}, error)
+		wantMethod string
+		wantPath   string
+	}{
+		{
+			name: "List",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				return s.List(context.Background())
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/plugins",
+		},
+		{
+			name: "Get",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				c.resp = Info{Name: "linter"}
+				return s.Get(context.Background(), "linter")
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/plugins/linter",
+		},
+		{
+			name: "Install",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				c.resp = Info{Name: "linter"}
+				return s.Install(context.Background(), "linter", nil)
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/plugins/install",
+		},
+		{
+			name: "Uninstall",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				return s.Uninstall(context.Background(), "linter")
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/plugins/linter/uninstall",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeClient{}
+			s := New(c)
+			_, err := tc.call(s, c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMethod, c.method)
+			assert.Equal(t, tc.wantPath, c.path)
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/dboone323/tools-automation/sdk/go/agents"
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// TaskEvent is delivered on a TaskSubscription as tasks progress.
+type TaskEvent struct {
+	Type     string     `json:"type"` // e.g. "task.progress", "task.completed", "task.failed"
+	Task     tasks.Info `json:"task"`
+	Progress float64    `json:"progress,omitempty"`
+}
+
+// AgentEvent is delivered on an AgentSubscription as agents change state.
+type AgentEvent struct {
+	Type  string        `json:"type"` // e.g. "agent.status_changed", "agent.heartbeat"
+	Agent agents.Status `json:"agent"`
+}
+
+// TaskProgressEvent is delivered on the channel returned by
+// StreamTaskProgress. It shares TaskEvent's shape since a single task's
+// stream carries the same progress/completed/failed event kinds as the
+// multi-task feed.
+type TaskProgressEvent = TaskEvent
+
+// WebhookFiredEvent is delivered as part of Event when a registered webhook
+// has been dispatched by the server.
+type WebhookFiredEvent struct {
+	WebhookID string          `json:"webhookId"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+// Event is delivered on an EventSubscription returned by SubscribeEvents. It
+// is a discriminated union over every event kind the server's /events feed
+// can emit; only the field matching Type is populated.
+type Event struct {
+	Type    string             `json:"type"` // e.g. "agent.status_changed", "agent.heartbeat", "webhook.fired"
+	Agent   *agents.Status     `json:"agent,omitempty"`
+	Webhook *WebhookFiredEvent `json:"webhook,omitempty"`
+}
+
+// TaskFilter narrows a task subscription to a status and/or agent. Empty
+// fields are not sent, matching the server's default of "all tasks".
+type TaskFilter struct {
+	Status string
+	Agent  string
+}
+
+func (f TaskFilter) queryString() string {
+	q := url.Values{}
+	if f.Status != "" {
+		q.Set("status", f.Status)
+	}
+	if f.Agent != "" {
+		q.Set("agent", f.Agent)
+	}
+	return q.Encode()
+}
+
+// SubscriptionStats reports backpressure and connection-health counters for
+// a subscription, via its Stats method.
+type SubscriptionStats struct {
+	DroppedEvents uint64
+	Reconnects    uint64
+}
+
+const defaultEventChannelCapacity = 64
+
+// subscription is the shared implementation behind TaskSubscription and
+// AgentSubscription: a buffered, typed event channel plus a separate error
+// channel so a transport hiccup doesn't have to silently close the data
+// channel out from under the caller.
+type subscription[T any] struct {
+	events chan T
+	errs   chan error
+	cancel context.CancelFunc
+
+	dropped    uint64
+	reconnects uint64
+}
+
+// Events returns the channel events are delivered on. It is closed once the
+// subscription's context is cancelled and the underlying transport has shut
+// down.
+func (s *subscription[T]) Events() <-chan T { return s.events }
+
+// Errors returns transport-level errors (failed reconnects, malformed
+// frames) encountered while streaming. It is not closed when Events is;
+// callers that don't care about errors may simply not read from it.
+func (s *subscription[T]) Errors() <-chan error { return s.errs }
+
+// Stats reports how many events have been dropped due to a full channel and
+// how many times the subscription has reconnected.
+func (s *subscription[T]) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		DroppedEvents: atomic.LoadUint64(&s.dropped),
+		Reconnects:    atomic.LoadUint64(&s.reconnects),
+	}
+}
+
+// Close ends the subscription and releases its connection.
+func (s *subscription[T]) Close() { s.cancel() }
+
+func (s *subscription[T]) deliver(v T) {
+	select {
+	case s.events <- v:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+func (s *subscription[T]) reportError(err error) {
+	select {
+	case s.errs <- err:
+	default:
+		// Errors channel full; drop rather than block the read loop.
+	}
+}
+
+// TaskSubscription is returned by SubscribeTasks.
+type TaskSubscription = subscription[TaskEvent]
+
+// AgentSubscription is returned by SubscribeAgentStatus.
+type AgentSubscription = subscription[AgentEvent]
+
+// EventSubscription is returned by SubscribeEvents.
+type EventSubscription = subscription[Event]
+
+func (c *Client) eventChannelCapacity() int {
+	if c.opts.EventChannelCapacity > 0 {
+		return c.opts.EventChannelCapacity
+	}
+	return defaultEventChannelCapacity
+}
+
+// SubscribeTasks opens a long-lived connection to /api/tasks/stream and
+// delivers task lifecycle events matching filter. By default it streams
+// over Server-Sent Events; set ClientOptions.PreferWebSocket to use a
+// WebSocket connection instead. The subscription reconnects automatically
+// on a dropped connection, resuming from the last event it saw.
+//
+// opts is honored only for its initial connection attempt: transport.WithTimeout
+// and transport.WithDeadline bound how long that first connect may take,
+// failing the returned error if it's exceeded. They do not bound the
+// subscription's lifetime or apply to later reconnects - use ctx for that.
+// transport.WithRetry, WithIdempotencyKey, and WithHeader don't apply to a
+// streaming connection and are ignored.
+func (c *Client) SubscribeTasks(ctx context.Context, filter TaskFilter, opts ...transport.CallOption) (*TaskSubscription, error) {
+	path := "/api/tasks/stream"
+	if qs := filter.queryString(); qs != "" {
+		path += "?" + qs
+	}
+	return subscribe[TaskEvent](c, ctx, path, opts...)
+}
+
+// SubscribeAgentStatus opens a long-lived connection to /api/agents/stream
+// and delivers agent status events. It behaves like SubscribeTasks in every
+// other respect, including how opts is honored.
+func (c *Client) SubscribeAgentStatus(ctx context.Context, opts ...transport.CallOption) (*AgentSubscription, error) {
+	return subscribe[AgentEvent](c, ctx, "/api/agents/stream", opts...)
+}
+
+// StreamTaskProgress opens a long-lived connection to /tasks/{taskID}/stream
+// and returns a channel of progress events for that task alone, eliminating
+// the need to busy-poll c.Tasks.Get. The channel is closed when ctx is
+// cancelled or the task reaches a terminal state and the server ends the
+// stream; it reconnects automatically (with Last-Event-ID resumption) on a
+// dropped connection in the meantime. opts is honored like SubscribeTasks'.
+func (c *Client) StreamTaskProgress(ctx context.Context, taskID string, opts ...transport.CallOption) (<-chan TaskProgressEvent, error) {
+	sub, err := subscribe[TaskProgressEvent](c, ctx, fmt.Sprintf("/tasks/%s/stream", taskID), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sub.Events(), nil
+}
+
+// SubscribeEvents opens a long-lived connection to /events and delivers
+// agent and webhook events as they occur. It behaves like SubscribeTasks in
+// every other respect, including reconnection, backoff, and how opts is
+// honored.
+func (c *Client) SubscribeEvents(ctx context.Context, opts ...transport.CallOption) (*EventSubscription, error) {
+	return subscribe[Event](c, ctx, "/events", opts...)
+}
+
+// subscribe starts the transport loop for T and returns immediately; the
+// first connection attempt happens in the background so a slow or
+// momentarily-down server doesn't block the caller. connectCfg, resolved
+// from opts, bounds only that first attempt - see SubscribeTasks.
+func subscribe[T any](c *Client, ctx context.Context, path string, opts ...transport.CallOption) (*subscription[T], error) {
+	connectCfg := transport.ApplyCallOptions(opts)
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &subscription[T]{
+		events: make(chan T, c.eventChannelCapacity()),
+		errs:   make(chan error, c.eventChannelCapacity()),
+		cancel: cancel,
+	}
+
+	if c.opts.PreferWebSocket {
+		go runWebSocketLoop(subCtx, c, path, sub, connectCfg)
+	} else {
+		go runSSELoop(subCtx, c, path, sub, connectCfg)
+	}
+
+	return sub, nil
+}
+
+// runSSELoop maintains an SSE connection to path, reconnecting with
+// exponential backoff and resuming via Last-Event-ID when the connection
+// drops, until ctx is cancelled. connectCfg bounds only the first attempt;
+// see streamSSEOnce.
+func runSSELoop[T any](ctx context.Context, c *Client, path string, sub *subscription[T], connectCfg transport.CallConfig) {
+	defer close(sub.events)
+
+	backoff := 500 * time.Millisecond
+	const maxBackoff = 30 * time.Second
+	lastEventID := ""
+
+	for attempt := 0; ; attempt++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		cfg := transport.CallConfig{}
+		if attempt == 0 {
+			cfg = connectCfg
+		}
+		err := streamSSEOnce(ctx, c, path, &lastEventID, sub, cfg)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			sub.reportError(err)
+		}
+
+		atomic.AddUint64(&sub.reconnects, 1)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// streamSSEOnce performs a single SSE connection attempt and blocks until
+// it ends (server close, network error, or ctx cancellation). connectCfg's
+// deadline/timeout, if set, bounds only reaching a response to the initial
+// GET - it is disarmed the moment that response arrives, so it never cuts
+// off an already-flowing stream the way reusing it as the request's
+// context for the whole read would.
+func streamSSEOnce[T any](ctx context.Context, c *Client, path string, lastEventID *string, sub *subscription[T], connectCfg transport.CallConfig) error {
+	connCtx, cancelConn := context.WithCancel(ctx)
+	defer cancelConn()
+
+	var connected chan struct{}
+	if !connectCfg.Deadline.IsZero() || connectCfg.Timeout > 0 {
+		timeoutCtx, cancelTimeout := connectCfg.Context(ctx)
+		defer cancelTimeout()
+		connected = make(chan struct{})
+		go func() {
+			select {
+			case <-timeoutCtx.Done():
+				cancelConn()
+			case <-connected:
+			}
+		}()
+	}
+
+	req, err := http.NewRequestWithContext(connCtx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+	for k, v := range c.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	if c.auth != nil {
+		if err := c.ensureToken(ctx); err != nil {
+			return err
+		}
+		c.auth.mu.Lock()
+		token := c.auth.token
+		c.auth.mu.Unlock()
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.GetClient().Do(req)
+	if connected != nil {
+		close(connected)
+	}
+	if err != nil {
+		return ConnectionError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("mcp: stream returned status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var dataLines []string
+	flush := func() {
+		if len(dataLines) == 0 {
+			return
+		}
+		var event T
+		if err := json.Unmarshal([]byte(strings.Join(dataLines, "\n")), &event); err != nil {
+			sub.reportError(fmt.Errorf("mcp: failed to decode stream event: %w", err))
+		} else {
+			sub.deliver(event)
+		}
+		dataLines = nil
+	}
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "id:"):
+			*lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "event:"), strings.HasPrefix(line, ":"):
+			// Event-name and comment lines aren't needed to decode the
+			// typed payload; ignored.
+		}
+	}
+	flush()
+
+	return scanner.Err()
+}
+
+// runWebSocketLoop is the WebSocket counterpart of runSSELoop, used when
+// ClientOptions.PreferWebSocket is set. connectCfg bounds only the first
+// attempt; see streamWebSocketOnce.
+func runWebSocketLoop[T any](ctx context.Context, c *Client, path string, sub *subscription[T], connectCfg transport.CallConfig) {
+	defer close(sub.events)
+
+	backoff := 500 * time.Millisecond
+	const maxBackoff = 30 * time.Second
+	lastEventID := ""
+
+	for attempt := 0; ; attempt++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		cfg := transport.CallConfig{}
+		if attempt == 0 {
+			cfg = connectCfg
+		}
+		err := streamWebSocketOnce(ctx, c, path, &lastEventID, sub, cfg)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			sub.reportError(err)
+		}
+
+		atomic.AddUint64(&sub.reconnects, 1)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// streamWebSocketOnce performs a single WebSocket connection attempt and
+// blocks until it ends. connectCfg's deadline/timeout, if set, bounds the
+// dial via DialContext; unlike SSE's http.Client.Do, DialContext's ctx only
+// governs the handshake and has no further effect on reads once the
+// connection is established, so it's applied directly with no disarming
+// needed.
+func streamWebSocketOnce[T any](ctx context.Context, c *Client, path string, lastEventID *string, sub *subscription[T], connectCfg transport.CallConfig) error {
+	wsURL, err := toWebSocketURL(c.baseURL, path)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	for k, v := range c.opts.Headers {
+		header.Set(k, v)
+	}
+	if *lastEventID != "" {
+		header.Set("Last-Event-ID", *lastEventID)
+	}
+	if c.auth != nil {
+		if err := c.ensureToken(ctx); err != nil {
+			return err
+		}
+		c.auth.mu.Lock()
+		token := c.auth.token
+		c.auth.mu.Unlock()
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	dialCtx, cancelDial := connectCfg.Context(ctx)
+	defer cancelDial()
+	conn, _, err := websocket.DefaultDialer.DialContext(dialCtx, wsURL, header)
+	if err != nil {
+		return ConnectionError{Err: err}
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		var envelope struct {
+			ID string `json:"id,omitempty"`
+		}
+		_ = json.Unmarshal(data, &envelope)
+		if envelope.ID != "" {
+			*lastEventID = envelope.ID
+		}
+
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			sub.reportError(fmt.Errorf("mcp: failed to decode stream event: %w", err))
+			continue
+		}
+		sub.deliver(event)
+	}
+}
+
+// toWebSocketURL rewrites an http(s) base URL and path into the equivalent
+// ws(s) URL.
+func toWebSocketURL(baseURL, path string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("mcp: invalid base URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+
+	p, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("mcp: invalid stream path: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + p.Path
+	u.RawQuery = p.RawQuery
+
+	return u.String(), nil
+}
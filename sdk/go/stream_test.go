@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+func TestSubscribeTasksDeliversEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tasks/stream", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		fmt.Fprintf(w, "id: 1\ndata: {\"type\":\"task.completed\",\"task\":{\"id\":\"t1\",\"status\":\"completed\"}}\n\n")
+		flusher.Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.SubscribeTasks(ctx, TaskFilter{})
+	require.NoError(t, err)
+
+	select {
+	case ev := <-sub.Events():
+		assert.Equal(t, "task.completed", ev.Type)
+		assert.Equal(t, "t1", ev.Task.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for task event")
+	}
+
+	sub.Close()
+}
+
+func TestSubscribeTasksReconnectsOnDrop(t *testing.T) {
+	var connections int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		fmt.Fprintf(w, "id: %d\ndata: {\"type\":\"task.progress\",\"task\":{\"id\":\"t%d\"}}\n\n", n, n)
+		flusher.Flush()
+		// First connection closes immediately to force a reconnect.
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.SubscribeTasks(ctx, TaskFilter{})
+	require.NoError(t, err)
+
+	seen := map[string]bool{}
+	timeout := time.After(5 * time.Second)
+	for len(seen) < 2 {
+		select {
+		case ev := <-sub.Events():
+			seen[ev.Task.ID] = true
+		case <-timeout:
+			t.Fatalf("timed out waiting for reconnect events, saw %v", seen)
+		}
+	}
+
+	assert.GreaterOrEqual(t, sub.Stats().Reconnects, uint64(1))
+	sub.Close()
+}
+
+func TestSubscribeTasksConnectTimeoutAbortsSlowInitialConnect(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Slower than the connect timeout below; streamSSEOnce should
+			// abort before this returns rather than waiting it out.
+			time.Sleep(2 * time.Second)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		fmt.Fprintf(w, "id: 1\ndata: {\"type\":\"task.progress\",\"task\":{\"id\":\"t1\"}}\n\n")
+		flusher.Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.SubscribeTasks(ctx, TaskFilter{}, transport.WithTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	defer sub.Close()
+
+	select {
+	case streamErr := <-sub.Errors():
+		assert.Error(t, streamErr)
+	case ev := <-sub.Events():
+		t.Fatalf("did not expect an event before the connect timeout fired, got %+v", ev)
+	case <-time.After(time.Second):
+		t.Fatal("expected the slow initial connect to be aborted by the connect timeout")
+	}
+}
+
+func TestSubscriptionStatsReportsDroppedEvents(t *testing.T) {
+	sub := &subscription[TaskEvent]{
+		events: make(chan TaskEvent, 1),
+		errs:   make(chan error, 1),
+		cancel: func() {},
+	}
+
+	sub.deliver(TaskEvent{Type: "a"})
+	sub.deliver(TaskEvent{Type: "b"}) // channel already full, should be dropped
+
+	assert.EqualValues(t, 1, sub.Stats().DroppedEvents)
+}
+
+func TestStreamTaskProgressScopesToSingleTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tasks/t1/stream", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		fmt.Fprintf(w, "data: {\"type\":\"task.progress\",\"task\":{\"id\":\"t1\"},\"progress\":0.5}\n\n")
+		flusher.Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.StreamTaskProgress(ctx, "t1")
+	require.NoError(t, err)
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, "task.progress", ev.Type)
+		assert.Equal(t, 0.5, ev.Progress)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for task progress event")
+	}
+}
+
+func TestSubscribeEventsDeliversWebhookAndAgentEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/events", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		fmt.Fprintf(w, "data: {\"type\":\"webhook.fired\",\"webhook\":{\"webhookId\":\"wh1\",\"event\":\"task.completed\"}}\n\n")
+		flusher.Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.SubscribeEvents(ctx)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	select {
+	case ev := <-sub.Events():
+		assert.Equal(t, "webhook.fired", ev.Type)
+		require.NotNil(t, ev.Webhook)
+		assert.Equal(t, "wh1", ev.Webhook.WebhookID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for webhook event")
+	}
+}
+
+func TestToWebSocketURL(t *testing.T) {
+	got, err := toWebSocketURL("https://example.com", "/api/tasks/stream?status=queued")
+	require.NoError(t, err)
+	assert.Equal(t, "wss://example.com/api/tasks/stream?status=queued", got)
+
+	got, err = toWebSocketURL("http://localhost:5005", "/api/agents/stream")
+	require.NoError(t, err)
+	assert.Equal(t, "ws://localhost:5005/api/agents/stream", got)
+}
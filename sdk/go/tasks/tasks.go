@@ -0,0 +1,90 @@
+// Package tasks provides the MCP client's task resource operations:
+// submitting, querying, listing, and cancelling tasks. Construct a Service
+// with New, sharing the transport.Client backing *mcp.Client; mcp.Client.Tasks
+// embeds one for convenient access as c.Tasks.Submit(ctx, submission).
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// Info represents task information.
+type Info struct {
+	ID          string                 `json:"id"`
+	Status      string                 `json:"status"`
+	Type        string                 `json:"type"`
+	Agent       string                 `json:"agent"`
+	CreatedAt   string                 `json:"createdAt"`
+	CompletedAt string                 `json:"completedAt,omitempty"`
+	Result      map[string]interface{} `json:"result,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Priority    string                 `json:"priority,omitempty"`
+	Progress    float64                `json:"progress,omitempty"`
+}
+
+// Submission represents a task submission request.
+type Submission struct {
+	Type       string                 `json:"type"`
+	Target     string                 `json:"target,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Priority   string                 `json:"priority,omitempty"`
+	Agent      string                 `json:"agent,omitempty"`
+}
+
+// LogString returns a safe-to-log representation of t.
+func (t Submission) LogString() string { return transport.LogString(t) }
+
+// Service provides task resource operations against a shared
+// transport.Client.
+type Service struct {
+	client transport.Client
+}
+
+// New creates a Service backed by client.
+func New(client transport.Client) *Service {
+	return &Service{client: client}
+}
+
+// Submit submits a task for processing. Pass transport.WithIdempotencyKey
+// so a retried POST (whether from a transport.WithRetry override or the
+// client's global policy) doesn't submit the task twice.
+func (s *Service) Submit(ctx context.Context, task Submission, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodPost, "/run", task, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Get retrieves the status of a task.
+func (s *Service) Get(ctx context.Context, taskID string, opts ...transport.CallOption) (*Info, error) {
+	var result Info
+	path := fmt.Sprintf("/tasks/%s", taskID)
+	if err := s.client.Do(ctx, http.MethodGet, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// List lists tasks with optional filtering.
+func (s *Service) List(ctx context.Context, status, agent string, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodGet, "/api/tasks/analytics", nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Cancel cancels a running task.
+func (s *Service) Cancel(ctx context.Context, taskID string, opts ...transport.CallOption) (*map[string]string, error) {
+	var result map[string]string
+	path := fmt.Sprintf("/tasks/%s/cancel", taskID)
+	if err := s.client.Do(ctx, http.MethodPost, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
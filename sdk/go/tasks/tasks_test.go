@@ -0,0 +1,93 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+type fakeClient struct {
+	method, path string
+	body         interface{}
+	resp         interface{}
+	err          error
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, path string, body, result interface{}, opts ...transport.CallOption) error {
+	f.method, f.path, f.body = method, path, body
+	if f.err != nil {
+		return f.err
+	}
+	if f.resp != nil && result != nil {
+		b, _ := json.Marshal(f.resp)
+		return json.Unmarshal(b, result)
+	}
+	return nil
+}
+
+func TestServiceMethodsIssueExpectedRequests(t *testing.T) {
+	cases := []struct {
+		name       string
+		call       func(s *Service, c *fakeClient) (interface{}, error)
+		wantMethod string
+		wantPath   string
+	}{
+		{
+			name: "Submit",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				return s.Submit(context.Background(), Submission{Type: "code_analysis"})
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/run",
+		},
+		{
+			name: "Get",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				c.resp = Info{ID: "task-1", Status: "completed"}
+				return s.Get(context.Background(), "task-1")
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/tasks/task-1",
+		},
+		{
+			name: "List",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				return s.List(context.Background(), "completed", "")
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/api/tasks/analytics",
+		},
+		{
+			name: "Cancel",
+			call: func(s *Service, c *fakeClient) (interface{}, error) {
+				return s.Cancel(context.Background(), "task-1")
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/tasks/task-1/cancel",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeClient{}
+			s := New(c)
+			_, err := tc.call(s, c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMethod, c.method)
+			assert.Equal(t, tc.wantPath, c.path)
+		})
+	}
+}
+
+func TestSubmissionLogStringRedactsNothingSensitiveButIncludesFields(t *testing.T) {
+	sub := Submission{Type: "code_analysis", Target: "main.go"}
+	out := sub.LogString()
+	assert.Contains(t, out, "code_analysis")
+	assert.Contains(t, out, "main.go")
+}
@@ -0,0 +1,581 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// Transport is how a Client speaks to an MCP server once dispatched through
+// a real JSON-RPC 2.0 exchange, as opposed to the bespoke REST surface Do
+// otherwise talks to. Set ClientOptions.Transport to have GetStatus,
+// c.Tasks.Submit, and the rest of the typed methods route through it
+// instead.
+type Transport interface {
+	// Call sends a JSON-RPC request and decodes its result into result
+	// (which may be nil to discard it). It blocks until a response
+	// arrives, ctx is cancelled, or the transport is closed. Do has
+	// already applied opts' deadline/timeout to ctx by the time Call sees
+	// it; Call only needs to consult opts for the parts ctx cannot carry -
+	// transport.WithIdempotencyKey and transport.WithHeader, which
+	// HTTPTransport and SSETransport attach to the underlying POST.
+	// StdioTransport has no per-call header mechanism to attach them to,
+	// so it accepts opts but cannot honor those two.
+	// transport.WithRetry is ignored everywhere here; Call makes one
+	// attempt and leaves retrying to the caller.
+	Call(ctx context.Context, method string, params interface{}, result interface{}, opts ...transport.CallOption) error
+	// Notify sends a JSON-RPC notification (no id, no response expected).
+	Notify(ctx context.Context, method string, params interface{}) error
+	// OnNotification registers handler to run when the server sends a
+	// notification for method (e.g. "tools/list_changed",
+	// "notifications/progress"). Only one handler per method is kept;
+	// registering again replaces it.
+	OnNotification(method string, handler func(params json.RawMessage))
+	// Close releases the transport's underlying connection or process.
+	Close() error
+}
+
+// jsonrpcMessage is the wire format for both requests/responses and
+// server-initiated notifications; which fields are populated depends on
+// which of those it is.
+type jsonrpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+type jsonrpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *jsonrpcError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+func encodeParams(params interface{}) (json.RawMessage, error) {
+	if params == nil {
+		return nil, nil
+	}
+	return json.Marshal(params)
+}
+
+// pendingRequests correlates in-flight Call invocations with their
+// responses by id. It is shared by every Transport implementation below.
+type pendingRequests struct {
+	mu      sync.Mutex
+	nextID  int64
+	waiters map[int64]chan jsonrpcMessage
+}
+
+func newPendingRequests() *pendingRequests {
+	return &pendingRequests{waiters: make(map[int64]chan jsonrpcMessage)}
+}
+
+func (p *pendingRequests) register() (int64, chan jsonrpcMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id := atomic.AddInt64(&p.nextID, 1)
+	ch := make(chan jsonrpcMessage, 1)
+	p.waiters[id] = ch
+	return id, ch
+}
+
+func (p *pendingRequests) cancel(id int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.waiters, id)
+}
+
+func (p *pendingRequests) resolve(msg jsonrpcMessage) bool {
+	if msg.ID == nil {
+		return false
+	}
+	p.mu.Lock()
+	ch, ok := p.waiters[*msg.ID]
+	if ok {
+		delete(p.waiters, *msg.ID)
+	}
+	p.mu.Unlock()
+	if ok {
+		ch <- msg
+	}
+	return ok
+}
+
+// notificationDispatcher routes server-initiated notifications (messages
+// with a method but no id) to registered handlers.
+type notificationDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]func(json.RawMessage)
+}
+
+func newNotificationDispatcher() *notificationDispatcher {
+	return &notificationDispatcher{handlers: make(map[string]func(json.RawMessage))}
+}
+
+func (d *notificationDispatcher) on(method string, handler func(json.RawMessage)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[method] = handler
+}
+
+func (d *notificationDispatcher) dispatch(msg jsonrpcMessage) bool {
+	if msg.Method == "" || msg.ID != nil {
+		return false
+	}
+	d.mu.RLock()
+	handler, ok := d.handlers[msg.Method]
+	d.mu.RUnlock()
+	if ok {
+		handler(msg.Params)
+	}
+	return true
+}
+
+// resolveResult unmarshals a successful response's Result into result, or
+// returns the RPC-level error it carried.
+func resolveResult(msg jsonrpcMessage, result interface{}) error {
+	if msg.Error != nil {
+		return msg.Error
+	}
+	if result != nil && len(msg.Result) > 0 {
+		if err := json.Unmarshal(msg.Result, result); err != nil {
+			return fmt.Errorf("mcp: failed to decode jsonrpc result: %w", err)
+		}
+	}
+	return nil
+}
+
+// StdioTransport speaks JSON-RPC 2.0 over the stdin/stdout of a spawned MCP
+// server subprocess, framing each message as a single line of JSON.
+type StdioTransport struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	writeMu sync.Mutex
+
+	pending *pendingRequests
+	notifs  *notificationDispatcher
+	logger  Logger
+
+	closeOnce sync.Once
+}
+
+// NewStdioTransport spawns command (with args) and speaks JSON-RPC over its
+// stdin/stdout. Its stderr is routed line-by-line to logger (or discarded if
+// logger is nil).
+func NewStdioTransport(ctx context.Context, command string, args []string, logger Logger) (*StdioTransport, error) {
+	if logger == nil {
+		logger = noopLogger{}
+	}
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("mcp: failed to open stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("mcp: failed to open stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("mcp: failed to open stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("mcp: failed to start stdio transport command: %w", err)
+	}
+
+	t := &StdioTransport{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: newPendingRequests(),
+		notifs:  newNotificationDispatcher(),
+		logger:  logger,
+	}
+
+	go t.readLoop(stdout)
+	go t.logStderr(stderr)
+
+	return t, nil
+}
+
+func (t *StdioTransport) readLoop(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var msg jsonrpcMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			t.logger.Warn("stdio transport: failed to decode message", "error", err.Error())
+			continue
+		}
+		if t.notifs.dispatch(msg) {
+			continue
+		}
+		t.pending.resolve(msg)
+	}
+}
+
+func (t *StdioTransport) logStderr(stderr io.Reader) {
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		t.logger.Info("stdio transport stderr", "line", scanner.Text())
+	}
+}
+
+func (t *StdioTransport) writeLine(msg jsonrpcMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("mcp: failed to marshal jsonrpc message: %w", err)
+	}
+	body = append(body, '\n')
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, err = t.stdin.Write(body)
+	return err
+}
+
+// Call implements Transport. opts' idempotency key and extra headers are
+// accepted but have no effect - stdio JSON-RPC framing has no header
+// analog to attach them to.
+func (t *StdioTransport) Call(ctx context.Context, method string, params interface{}, result interface{}, opts ...transport.CallOption) error {
+	encoded, err := encodeParams(params)
+	if err != nil {
+		return err
+	}
+
+	id, ch := t.pending.register()
+	if err := t.writeLine(jsonrpcMessage{JSONRPC: "2.0", ID: &id, Method: method, Params: encoded}); err != nil {
+		t.pending.cancel(id)
+		return ConnectionError{Err: err}
+	}
+
+	select {
+	case msg := <-ch:
+		return resolveResult(msg, result)
+	case <-ctx.Done():
+		t.pending.cancel(id)
+		return ctx.Err()
+	}
+}
+
+// Notify implements Transport.
+func (t *StdioTransport) Notify(ctx context.Context, method string, params interface{}) error {
+	encoded, err := encodeParams(params)
+	if err != nil {
+		return err
+	}
+	return t.writeLine(jsonrpcMessage{JSONRPC: "2.0", Method: method, Params: encoded})
+}
+
+// OnNotification implements Transport.
+func (t *StdioTransport) OnNotification(method string, handler func(params json.RawMessage)) {
+	t.notifs.on(method, handler)
+}
+
+// Close implements Transport, terminating the spawned process.
+func (t *StdioTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		_ = t.stdin.Close()
+		if t.cmd.Process != nil {
+			err = t.cmd.Process.Kill()
+		}
+	})
+	return err
+}
+
+// HTTPTransport speaks JSON-RPC 2.0 by POSTing a request and parsing the
+// JSON-RPC response from the same HTTP response body. It has no channel for
+// server-initiated notifications; OnNotification handlers are never called.
+type HTTPTransport struct {
+	url        string
+	httpClient *http.Client
+	headers    map[string]string
+
+	mu     sync.Mutex
+	nextID int64
+}
+
+// NewHTTPTransport creates an HTTPTransport posting JSON-RPC requests to
+// url.
+func NewHTTPTransport(url string, httpClient *http.Client, headers map[string]string) *HTTPTransport {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPTransport{url: url, httpClient: httpClient, headers: headers}
+}
+
+// do posts msg as the JSON-RPC request body. cfg's idempotency key and
+// extra headers, if any, are set on top of t.headers, letting a single call
+// override the transport-wide defaults the way doRequest's REST path does.
+func (t *HTTPTransport) do(ctx context.Context, msg jsonrpcMessage, cfg transport.CallConfig) (jsonrpcMessage, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return jsonrpcMessage{}, fmt.Errorf("mcp: failed to marshal jsonrpc message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, strings.NewReader(string(body)))
+	if err != nil {
+		return jsonrpcMessage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	if cfg.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", cfg.IdempotencyKey)
+	}
+	for k, v := range cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return jsonrpcMessage{}, ConnectionError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return jsonrpcMessage{}, fmt.Errorf("mcp: failed to read jsonrpc response: %w", err)
+	}
+	if len(respBody) == 0 {
+		return jsonrpcMessage{}, nil
+	}
+
+	var out jsonrpcMessage
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return jsonrpcMessage{}, fmt.Errorf("mcp: failed to decode jsonrpc response: %w", err)
+	}
+	return out, nil
+}
+
+// Call implements Transport. opts' idempotency key and extra headers are
+// set on the underlying POST; its deadline/timeout and retry policy are
+// ignored here since Do has already applied the former to ctx and owns
+// retrying on its own.
+func (t *HTTPTransport) Call(ctx context.Context, method string, params interface{}, result interface{}, opts ...transport.CallOption) error {
+	encoded, err := encodeParams(params)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.nextID++
+	id := t.nextID
+	t.mu.Unlock()
+
+	cfg := transport.ApplyCallOptions(opts)
+	resp, err := t.do(ctx, jsonrpcMessage{JSONRPC: "2.0", ID: &id, Method: method, Params: encoded}, cfg)
+	if err != nil {
+		return err
+	}
+	return resolveResult(resp, result)
+}
+
+// Notify implements Transport.
+func (t *HTTPTransport) Notify(ctx context.Context, method string, params interface{}) error {
+	encoded, err := encodeParams(params)
+	if err != nil {
+		return err
+	}
+	_, err = t.do(ctx, jsonrpcMessage{JSONRPC: "2.0", Method: method, Params: encoded}, transport.CallConfig{})
+	return err
+}
+
+// OnNotification implements Transport. HTTPTransport has no channel for
+// server-initiated messages, so handlers are accepted but never invoked;
+// use SSETransport for that.
+func (t *HTTPTransport) OnNotification(string, func(json.RawMessage)) {}
+
+// Close implements Transport. HTTPTransport holds no persistent connection.
+func (t *HTTPTransport) Close() error { return nil }
+
+// SSETransport is an HTTPTransport that additionally keeps a GET request to
+// sseURL open as a Server-Sent Events stream, so the server can push
+// notifications and streamed partial results the way StdioTransport's
+// bidirectional pipe allows.
+type SSETransport struct {
+	*HTTPTransport
+
+	notifs *notificationDispatcher
+	cancel context.CancelFunc
+}
+
+// NewSSETransport creates an SSETransport that POSTs JSON-RPC requests to
+// postURL and listens for server-initiated messages on sseURL.
+func NewSSETransport(ctx context.Context, postURL, sseURL string, httpClient *http.Client, headers map[string]string, logger Logger) (*SSETransport, error) {
+	if logger == nil {
+		logger = noopLogger{}
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	t := &SSETransport{
+		HTTPTransport: NewHTTPTransport(postURL, httpClient, headers),
+		notifs:        newNotificationDispatcher(),
+		cancel:        cancel,
+	}
+
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, sseURL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.HTTPTransport.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, ConnectionError{Err: err}
+	}
+
+	go t.readSSE(resp.Body, logger)
+
+	return t, nil
+}
+
+func (t *SSETransport) readSSE(body io.ReadCloser, logger Logger) {
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var dataLines []string
+	flush := func() {
+		if len(dataLines) == 0 {
+			return
+		}
+		defer func() { dataLines = nil }()
+
+		var msg jsonrpcMessage
+		if err := json.Unmarshal([]byte(strings.Join(dataLines, "\n")), &msg); err != nil {
+			logger.Warn("sse transport: failed to decode message", "error", err.Error())
+			return
+		}
+		t.notifs.dispatch(msg)
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	flush()
+}
+
+// OnNotification implements Transport, overriding the embedded
+// HTTPTransport's no-op to dispatch messages seen on the SSE stream.
+func (t *SSETransport) OnNotification(method string, handler func(params json.RawMessage)) {
+	t.notifs.on(method, handler)
+}
+
+// Close implements Transport, ending the SSE stream.
+func (t *SSETransport) Close() error {
+	t.cancel()
+	return nil
+}
+
+// rpcMethodForPath maps one of the Client's REST (httpMethod, path) pairs to
+// the JSON-RPC method name Do calls through c.transport when one is
+// configured, so the typed methods (GetStatus, c.Tasks.Submit, ...) don't
+// need to know which wire format is in play.
+func rpcMethodForPath(httpMethod, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case path == "/status":
+		return "status/get"
+	case path == "/health":
+		return "health/check"
+	case path == "/api/agents/status":
+		return "agents/list"
+	case path == "/agents" && httpMethod == http.MethodPost:
+		return "agents/register"
+	case len(segments) == 2 && segments[0] == "agents":
+		return "agents/get"
+	case path == "/run":
+		return "tasks/submit"
+	case path == "/api/tasks/analytics":
+		return "tasks/list"
+	case len(segments) == 3 && segments[0] == "tasks" && segments[2] == "cancel":
+		return "tasks/cancel"
+	case len(segments) == 2 && segments[0] == "tasks":
+		return "tasks/get"
+	case path == "/ai/analyze":
+		return "ai/analyzeCode"
+	case path == "/ai/predict":
+		return "ai/predictPerformance"
+	case path == "/ai/generate":
+		return "ai/generateCode"
+	case path == "/webhooks" && httpMethod == http.MethodPost:
+		return "webhooks/register"
+	case path == "/webhooks" && httpMethod == http.MethodGet:
+		return "webhooks/list"
+	case len(segments) == 2 && segments[0] == "webhooks":
+		return "webhooks/delete"
+	case path == "/plugins":
+		return "plugins/list"
+	case path == "/plugins/install":
+		return "plugins/install"
+	case len(segments) == 3 && segments[0] == "plugins" && segments[2] == "uninstall":
+		return "plugins/uninstall"
+	case len(segments) == 2 && segments[0] == "plugins":
+		return "plugins/get"
+	default:
+		return httpMethod + " " + path
+	}
+}
+
+// rpcParamsForPath derives the JSON-RPC params for a call whose REST
+// counterpart carries its identifier in the path rather than the body (e.g.
+// GET /agents/{name}), falling back to the REST body when there is one.
+func rpcParamsForPath(path string, body interface{}) interface{} {
+	if body != nil {
+		return body
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return nil
+	}
+
+	switch segments[0] {
+	case "agents":
+		return map[string]interface{}{"name": segments[1]}
+	case "tasks":
+		return map[string]interface{}{"taskId": segments[1]}
+	case "webhooks":
+		return map[string]interface{}{"webhookId": segments[1]}
+	case "plugins":
+		return map[string]interface{}{"name": segments[1]}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy controls how a single call is retried after a retryable
+// error. It overrides the client's global retry count/backoff for that one
+// call, which matters for POSTs like /run or /plugins/install where a
+// blind, globally-configured retry could duplicate the request.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// CallConfig is the resolved configuration for a single call, built by
+// applying a CallOption slice with ApplyCallOptions. A Client
+// implementation's Do method consults it to derive the request's deadline,
+// retry policy, idempotency key, and extra headers.
+type CallConfig struct {
+	Timeout        time.Duration
+	Deadline       time.Time
+	Retry          *RetryPolicy
+	IdempotencyKey string
+	Headers        map[string]string
+}
+
+// CallOption configures a single Client.Do call without changing the
+// client's global ClientOptions. Construct one with WithTimeout,
+// WithDeadline, WithRetry, WithIdempotencyKey, or WithHeader.
+type CallOption func(*CallConfig)
+
+// WithTimeout bounds the call to d, relative to when it starts. It is
+// ignored when WithDeadline is also supplied.
+func WithTimeout(d time.Duration) CallOption {
+	return func(c *CallConfig) { c.Timeout = d }
+}
+
+// WithDeadline bounds the call to the absolute time t, taking precedence
+// over WithTimeout.
+func WithDeadline(t time.Time) CallOption {
+	return func(c *CallConfig) { c.Deadline = t }
+}
+
+// WithRetry overrides the client's global retry count and backoff for this
+// call alone.
+func WithRetry(policy RetryPolicy) CallOption {
+	return func(c *CallConfig) { c.Retry = &policy }
+}
+
+// WithIdempotencyKey attaches an Idempotency-Key header carrying key, so the
+// server can safely de-duplicate a POST that gets retried after its
+// response was lost in transit (e.g. /run, /plugins/install).
+func WithIdempotencyKey(key string) CallOption {
+	return func(c *CallConfig) { c.IdempotencyKey = key }
+}
+
+// WithHeader sets an additional header on the call's request.
+func WithHeader(key, value string) CallOption {
+	return func(c *CallConfig) {
+		if c.Headers == nil {
+			c.Headers = make(map[string]string)
+		}
+		c.Headers[key] = value
+	}
+}
+
+// ApplyCallOptions builds a CallConfig by applying opts in order.
+func ApplyCallOptions(opts []CallOption) CallConfig {
+	var cfg CallConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Context derives a child context from ctx honoring cfg's deadline or
+// timeout, mirroring how net.Conn.SetDeadline governs a single operation
+// without disturbing other pending deadlines on the same connection. If
+// neither is set, ctx is returned unchanged with a no-op cancel.
+func (cfg CallConfig) Context(ctx context.Context) (context.Context, context.CancelFunc) {
+	switch {
+	case !cfg.Deadline.IsZero():
+		return context.WithDeadline(ctx, cfg.Deadline)
+	case cfg.Timeout > 0:
+		return context.WithTimeout(ctx, cfg.Timeout)
+	default:
+		return ctx, func() {}
+	}
+}
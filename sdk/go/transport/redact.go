@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// redacted is substituted for any field considered sensitive before it
+// reaches a log line.
+const redacted = "***"
+
+// LogString marshals a secret-redacted copy of v to JSON, for use by a
+// resource type's LogString method. Any field tagged `sensitive:"true"`, or
+// named "Secret"/"secret", is replaced with redacted; it never returns an
+// error, degrading to a fixed placeholder so logging never breaks a
+// caller's control flow.
+func LogString(v interface{}) string {
+	b, err := json.Marshal(sanitize(v))
+	if err != nil {
+		return `"<unloggable>"`
+	}
+	return string(b)
+}
+
+func sanitize(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, rv.NumField())
+		t := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				if tag == "-" {
+					continue
+				}
+				if idx := indexComma(tag); idx >= 0 {
+					tag = tag[:idx]
+				}
+				if tag != "" {
+					name = tag
+				}
+			}
+			if field.Tag.Get("sensitive") == "true" || name == "Secret" || name == "secret" {
+				out[name] = redacted
+				continue
+			}
+			out[name] = sanitize(rv.Field(i).Interface())
+		}
+		return out
+	case reflect.Map:
+		out := make(map[string]interface{}, rv.Len())
+		for _, key := range rv.MapKeys() {
+			k := key.String()
+			if k == "Authorization" || k == "authorization" || k == "secret" || k == "Secret" {
+				out[k] = redacted
+				continue
+			}
+			out[k] = sanitize(rv.MapIndex(key).Interface())
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func indexComma(s string) int {
+	for i, c := range s {
+		if c == ',' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,17 @@
+// Package transport defines the seam the resource-oriented service
+// packages (agents, tasks, ai, webhooks, plugins) issue requests through.
+// Depending on this narrow interface instead of the mcp package directly
+// lets *mcp.Client embed a Service from each of those packages without an
+// import cycle back to mcp itself.
+package transport
+
+import "context"
+
+// Client is satisfied by *mcp.Client. It is the one method a resource
+// Service needs: issue a request against path, decoding its response (or,
+// when the underlying client is configured with a JSON-RPC transport, the
+// equivalent RPC call) into result. opts carries per-call deadline, retry,
+// idempotency, and header overrides; see CallOption.
+type Client interface {
+	Do(ctx context.Context, method, path string, body, result interface{}, opts ...CallOption) error
+}
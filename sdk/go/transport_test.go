@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+func TestRPCMethodForPath(t *testing.T) {
+	cases := []struct {
+		httpMethod string
+		path       string
+		want       string
+	}{
+		{http.MethodGet, "/status", "status/get"},
+		{http.MethodGet, "/health", "health/check"},
+		{http.MethodGet, "/agents/agent1", "agents/get"},
+		{http.MethodPost, "/agents", "agents/register"},
+		{http.MethodPost, "/run", "tasks/submit"},
+		{http.MethodGet, "/tasks/task-123", "tasks/get"},
+		{http.MethodPost, "/tasks/task-123/cancel", "tasks/cancel"},
+		{http.MethodPost, "/webhooks", "webhooks/register"},
+		{http.MethodGet, "/webhooks", "webhooks/list"},
+		{http.MethodDelete, "/webhooks/wh-1", "webhooks/delete"},
+		{http.MethodPost, "/plugins/install", "plugins/install"},
+		{http.MethodPost, "/plugins/foo/uninstall", "plugins/uninstall"},
+		{http.MethodGet, "/plugins/foo", "plugins/get"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, rpcMethodForPath(tc.httpMethod, tc.path), "%s %s", tc.httpMethod, tc.path)
+	}
+}
+
+func TestRPCParamsForPathUsesPathSegmentWhenNoBody(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"name": "agent1"}, rpcParamsForPath("/agents/agent1", nil))
+	assert.Equal(t, map[string]interface{}{"taskId": "task-123"}, rpcParamsForPath("/tasks/task-123", nil))
+}
+
+func TestRPCParamsForPathPrefersBody(t *testing.T) {
+	body := map[string]interface{}{"type": "code_analysis"}
+	assert.Equal(t, body, rpcParamsForPath("/run", body))
+}
+
+// fakeTransport lets tests assert on what method/params Do sent without
+// spinning up a real subprocess or HTTP server.
+type fakeTransport struct {
+	lastMethod   string
+	lastParams   interface{}
+	lastConfig   transport.CallConfig
+	lastHasDline bool
+	result       interface{}
+	err          error
+}
+
+func (f *fakeTransport) Call(ctx context.Context, method string, params interface{}, result interface{}, opts ...transport.CallOption) error {
+	f.lastMethod = method
+	f.lastParams = params
+	f.lastConfig = transport.ApplyCallOptions(opts)
+	_, f.lastHasDline = ctx.Deadline()
+	if f.err != nil {
+		return f.err
+	}
+	if f.result != nil && result != nil {
+		b, _ := json.Marshal(f.result)
+		return json.Unmarshal(b, result)
+	}
+	return nil
+}
+
+func (f *fakeTransport) Notify(ctx context.Context, method string, params interface{}) error {
+	return nil
+}
+func (f *fakeTransport) OnNotification(string, func(json.RawMessage)) {}
+func (f *fakeTransport) Close() error                                 { return nil }
+
+func TestClientDispatchesThroughTransportWhenConfigured(t *testing.T) {
+	ft := &fakeTransport{result: ServerStatus{Status: "healthy", Version: "2.0.0"}}
+	client := NewClient("http://unused", &ClientOptions{Transport: ft})
+
+	status, err := client.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "status/get", ft.lastMethod)
+	assert.Equal(t, "healthy", status.Status)
+}
+
+func TestClientForwardsCallOptionsThroughTransport(t *testing.T) {
+	ft := &fakeTransport{result: ServerStatus{Status: "healthy"}}
+	client := NewClient("http://unused", &ClientOptions{Transport: ft})
+
+	_, err := client.GetStatus(context.Background(), transport.WithIdempotencyKey("req-1"), transport.WithHeader("X-Test", "1"))
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", ft.lastConfig.IdempotencyKey)
+	assert.Equal(t, "1", ft.lastConfig.Headers["X-Test"])
+}
+
+func TestClientAppliesCallOptionDeadlineBeforeDispatchingThroughTransport(t *testing.T) {
+	ft := &fakeTransport{result: ServerStatus{Status: "healthy"}}
+	client := NewClient("http://unused", &ClientOptions{Transport: ft})
+
+	_, err := client.GetStatus(context.Background(), transport.WithTimeout(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ft.lastHasDline, "expected ctx passed to Transport.Call to carry the WithTimeout deadline")
+}
+
+func TestHTTPTransportRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpcMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "status/get", req.Method)
+
+		result, _ := json.Marshal(map[string]string{"status": "healthy"})
+		resp := jsonrpcMessage{JSONRPC: "2.0", ID: req.ID, Result: result}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	transport := NewHTTPTransport(server.URL, nil, nil)
+	var out map[string]string
+	err := transport.Call(context.Background(), "status/get", nil, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", out["status"])
+}
+
+func TestHTTPTransportPropagatesRPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpcMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		resp := jsonrpcMessage{JSONRPC: "2.0", ID: req.ID, Error: &jsonrpcError{Code: -32601, Message: "method not found"}}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	transport := NewHTTPTransport(server.URL, nil, nil)
+	err := transport.Call(context.Background(), "bogus", nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "method not found")
+}
+
+func TestSSETransportDispatchesNotifications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			flusher := w.(http.Flusher)
+			notif, _ := json.Marshal(jsonrpcMessage{JSONRPC: "2.0", Method: "notifications/progress", Params: []byte(`{"percent":50}`)})
+			w.Write([]byte("data: "))
+			w.Write(notif)
+			w.Write([]byte("\n\n"))
+			flusher.Flush()
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0"}`))
+	}))
+	defer server.Close()
+
+	received := make(chan json.RawMessage, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport, err := NewSSETransport(ctx, server.URL, server.URL, nil, nil, nil)
+	require.NoError(t, err)
+	defer transport.Close()
+
+	transport.OnNotification("notifications/progress", func(params json.RawMessage) {
+		received <- params
+	})
+
+	select {
+	case params := <-received:
+		assert.JSONEq(t, `{"percent":50}`, string(params))
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
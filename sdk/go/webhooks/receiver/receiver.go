@@ -0,0 +1,294 @@
+// Package receiver provides an http.Handler for receiving webhook
+// deliveries registered via webhooks.Service.Register. It verifies the
+// HMAC-SHA256 signature the server sends with each delivery (using the
+// Secret passed at registration time), decodes the event payload into a
+// typed struct per event kind, and dispatches it to a registered handler.
+package receiver
+
+import (
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dboone323/tools-automation/sdk/go/tasks"
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// TaskCompletedEvent is delivered for the "task.completed" webhook event.
+type TaskCompletedEvent struct {
+	Task tasks.Info `json:"task"`
+}
+
+// AgentStatusChangedEvent is delivered for the "agent.status_changed"
+// webhook event.
+type AgentStatusChangedEvent struct {
+	Agent    string `json:"agent"`
+	OldState string `json:"oldState"`
+	NewState string `json:"newState"`
+}
+
+// SystemAlertEvent is delivered for the "system.alert" webhook event.
+type SystemAlertEvent struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// webhookEnvelope is the wire format an MCP server posts to a registered
+// webhook URL.
+type webhookEnvelope struct {
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// HandlerOptions configures a Handler.
+type HandlerOptions struct {
+	// Secret is the value supplied to webhooks.Registration; it must match
+	// for the signature check to pass.
+	Secret string
+	// MaxClockSkew bounds how far the required X-MCP-Timestamp header may
+	// drift from the local clock before a delivery is rejected as a
+	// possible replay; a delivery with no X-MCP-Timestamp at all is always
+	// rejected. Defaults to 5 minutes.
+	MaxClockSkew time.Duration
+	// IdempotencyCacheSize bounds the number of recently seen
+	// X-MCP-Delivery-ID values kept to suppress duplicate dispatch.
+	// Defaults to 1024.
+	IdempotencyCacheSize int
+	// Client is used by Replay to re-fetch past deliveries. Optional.
+	Client transport.Client
+}
+
+// Handler is an http.Handler that receives webhook deliveries, verifies
+// their signature, and dispatches typed events to handlers registered with
+// On (or the OnTaskCompleted/OnAgentStatusChanged convenience wrappers).
+type Handler struct {
+	opts     HandlerOptions
+	handlers sync.Map // event name -> reflect.Value of func(context.Context, T) error
+	payload  sync.Map // event name -> reflect.Type of T
+
+	seen *idempotencyCache
+}
+
+// NewHandler creates a Handler that verifies deliveries with opts.Secret.
+func NewHandler(opts HandlerOptions) *Handler {
+	if opts.MaxClockSkew == 0 {
+		opts.MaxClockSkew = 5 * time.Minute
+	}
+	if opts.IdempotencyCacheSize == 0 {
+		opts.IdempotencyCacheSize = 1024
+	}
+	return &Handler{
+		opts: opts,
+		seen: newIdempotencyCache(opts.IdempotencyCacheSize),
+	}
+}
+
+// On registers handler to run when a delivery for event arrives. handler
+// must have the signature func(context.Context, T) error for some event
+// payload type T (e.g. TaskCompletedEvent). It returns an error if handler
+// does not match that shape.
+func (h *Handler) On(event string, handler interface{}) error {
+	ht := reflect.TypeOf(handler)
+	if ht == nil || ht.Kind() != reflect.Func || ht.NumIn() != 2 || ht.NumOut() != 1 {
+		return fmt.Errorf("receiver: handler for %q must have signature func(context.Context, T) error", event)
+	}
+	if ht.In(0) != reflect.TypeOf((*context.Context)(nil)).Elem() {
+		return fmt.Errorf("receiver: handler for %q must accept context.Context as its first argument", event)
+	}
+	if ht.Out(0) != reflect.TypeOf((*error)(nil)).Elem() {
+		return fmt.Errorf("receiver: handler for %q must return error", event)
+	}
+
+	h.handlers.Store(event, reflect.ValueOf(handler))
+	h.payload.Store(event, ht.In(1))
+	return nil
+}
+
+// OnTaskCompleted registers fn to run whenever a "task.completed" delivery
+// arrives, unwrapping TaskCompletedEvent down to the task itself.
+func (h *Handler) OnTaskCompleted(fn func(tasks.Info)) error {
+	return h.On("task.completed", func(_ context.Context, ev TaskCompletedEvent) error {
+		fn(ev.Task)
+		return nil
+	})
+}
+
+// OnAgentStatusChanged registers fn to run whenever an
+// "agent.status_changed" delivery arrives.
+func (h *Handler) OnAgentStatusChanged(fn func(AgentStatusChangedEvent)) error {
+	return h.On("agent.status_changed", func(_ context.Context, ev AgentStatusChangedEvent) error {
+		fn(ev)
+		return nil
+	})
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	deliveryID := r.Header.Get("X-MCP-Delivery-ID")
+	if deliveryID != "" && h.seen.seenBefore(deliveryID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var env webhookEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), env); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks the HMAC-SHA256 signature and, if present, the timestamp
+// skew for a delivery.
+func (h *Handler) verify(r *http.Request, body []byte) error {
+	sigHeader := r.Header.Get("X-MCP-Signature")
+	const prefix = "sha256="
+	if len(sigHeader) <= len(prefix) || sigHeader[:len(prefix)] != prefix {
+		return fmt.Errorf("receiver: missing or malformed X-MCP-Signature header")
+	}
+	sig, err := hex.DecodeString(sigHeader[len(prefix):])
+	if err != nil {
+		return fmt.Errorf("receiver: malformed signature: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.opts.Secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	if !hmac.Equal(sig, expected) {
+		return fmt.Errorf("receiver: signature mismatch")
+	}
+
+	// The signature covers only the body, not the timestamp, so a missing
+	// X-MCP-Timestamp must be rejected rather than treated as "no skew
+	// check needed" - otherwise a captured (body, signature) pair could be
+	// replayed indefinitely by simply omitting the header.
+	ts := r.Header.Get("X-MCP-Timestamp")
+	if ts == "" {
+		return fmt.Errorf("receiver: missing X-MCP-Timestamp header")
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("receiver: malformed X-MCP-Timestamp: %w", err)
+	}
+	skew := time.Since(time.Unix(unix, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > h.opts.MaxClockSkew {
+		return fmt.Errorf("receiver: timestamp skew %s exceeds MaxClockSkew", skew)
+	}
+
+	return nil
+}
+
+// dispatch decodes env.Payload into the type registered for env.Event and
+// invokes its handler.
+func (h *Handler) dispatch(ctx context.Context, env webhookEnvelope) error {
+	handlerVal, ok := h.handlers.Load(env.Event)
+	if !ok {
+		// No handler registered for this event type; acknowledge anyway so
+		// the server doesn't retry deliveries we intentionally ignore.
+		return nil
+	}
+	payloadType, _ := h.payload.Load(env.Event)
+
+	payload := reflect.New(payloadType.(reflect.Type))
+	if len(env.Payload) > 0 {
+		if err := json.Unmarshal(env.Payload, payload.Interface()); err != nil {
+			return fmt.Errorf("receiver: failed to decode %s payload: %w", env.Event, err)
+		}
+	}
+
+	results := handlerVal.(reflect.Value).Call([]reflect.Value{
+		reflect.ValueOf(ctx),
+		payload.Elem(),
+	})
+	if errVal := results[0]; !errVal.IsNil() {
+		return errVal.Interface().(error)
+	}
+	return nil
+}
+
+// Replay re-fetches a past delivery by ID from the MCP server and dispatches
+// it through the registered handlers again, for debugging deliveries that
+// were missed or mishandled. It requires Client to have been set in
+// HandlerOptions.
+func (h *Handler) Replay(ctx context.Context, deliveryID string) error {
+	if h.opts.Client == nil {
+		return fmt.Errorf("receiver: Replay requires HandlerOptions.Client to be set")
+	}
+
+	var env webhookEnvelope
+	path := fmt.Sprintf("/webhooks/events/%s", deliveryID)
+	if err := h.opts.Client.Do(ctx, http.MethodGet, path, nil, &env); err != nil {
+		return err
+	}
+	return h.dispatch(ctx, env)
+}
+
+// idempotencyCache is a fixed-size LRU of delivery IDs already processed,
+// so retried deliveries don't double-fire handlers.
+type idempotencyCache struct {
+	mu       sync.Mutex
+	size     int
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+func newIdempotencyCache(size int) *idempotencyCache {
+	return &idempotencyCache{
+		size:     size,
+		order:    list.New(),
+		elements: make(map[string]*list.Element, size),
+	}
+}
+
+// seenBefore reports whether id has already been recorded, and records it
+// if not.
+func (c *idempotencyCache) seenBefore(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[id]; ok {
+		c.order.MoveToFront(el)
+		return true
+	}
+
+	el := c.order.PushFront(id)
+	c.elements[id] = el
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.elements, oldest.Value.(string))
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Package webhooks provides the MCP client's webhook management
+// operations: registering, listing, and deleting webhooks. For receiving
+// webhook deliveries the server sends back, see the receiver subpackage.
+// Construct a Service with New, sharing the transport.Client backing
+// *mcp.Client; mcp.Client.Webhooks embeds one for convenient access as
+// c.Webhooks.Register(ctx, registration).
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+// Registration represents a webhook registration.
+type Registration struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret,omitempty" sensitive:"true"`
+}
+
+// LogString returns a safe-to-log representation of r, with Secret
+// replaced by "***".
+func (r Registration) LogString() string { return transport.LogString(r) }
+
+// Service provides webhook management operations against a shared
+// transport.Client.
+type Service struct {
+	client transport.Client
+}
+
+// New creates a Service backed by client.
+func New(client transport.Client) *Service {
+	return &Service{client: client}
+}
+
+// Register registers a webhook for events.
+func (s *Service) Register(ctx context.Context, registration Registration, opts ...transport.CallOption) (*map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodPost, "/webhooks", registration, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// List lists registered webhooks.
+func (s *Service) List(ctx context.Context, opts ...transport.CallOption) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	if err := s.client.Do(ctx, http.MethodGet, "/webhooks", nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete deletes a webhook.
+func (s *Service) Delete(ctx context.Context, webhookID string, opts ...transport.CallOption) (*map[string]string, error) {
+	var result map[string]string
+	path := fmt.Sprintf("/webhooks/%s", webhookID)
+	if err := s.client.Do(ctx, http.MethodDelete, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
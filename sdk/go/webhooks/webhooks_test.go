@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dboone323/tools-automation/sdk/go/transport"
+)
+
+type fakeClient struct {
+	method, path string
+	body         interface{}
+	err          error
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, path string, body, result interface{}, opts ...transport.CallOption) error {
+	f.method, f.path, f.body = method, path, body
+	return f.err
+}
+
+func TestServiceMethodsIssueExpectedRequests(t *testing.T) {
+	cases := []struct {
+		name       string
+		call       func(s *Service) (interface{}, error)
+		wantMethod string
+		wantPath   string
+	}{
+		{
+			name: "Register",
+			call: func(s *Service) (interface{}, error) {
+				return s.Register(context.Background(), Registration{URL: "https://example.com/hook"})
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/webhooks",
+		},
+		{
+			name: "List",
+			call: func(s *Service) (interface{}, error) {
+				return s.List(context.Background())
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/webhooks",
+		},
+		{
+			name: "Delete",
+			call: func(s *Service) (interface{}, error) {
+				return s.Delete(context.Background(), "wh-1")
+			},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/webhooks/wh-1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeClient{}
+			s := New(c)
+			_, err := tc.call(s)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMethod, c.method)
+			assert.Equal(t, tc.wantPath, c.path)
+		})
+	}
+}
+
+func TestRegistrationLogStringRedactsSecret(t *testing.T) {
+	reg := Registration{URL: "https://example.com/hook", Secret: "super-secret"}
+	out := reg.LogString()
+	assert.NotContains(t, out, "super-secret")
+	assert.Contains(t, out, "https://example.com/hook")
+}